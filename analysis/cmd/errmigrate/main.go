@@ -0,0 +1,170 @@
+// Command errmigrate rewrites errors created with github.com/pkg/errors and fmt.Errorf("...: %w", err)
+// into CustomErrors, see migrate package. Changes are printed as unified diff unless -w flag is set,
+// calls that could not be rewritten safely are reported to stderr.
+//
+//	errmigrate ./...
+//	errmigrate -config migrate.yaml -w ./internal/...
+//
+// Config sets level and type of errors in packages:
+//
+//	rules:
+//	  - pattern: "*/repository/*"
+//	    level: DataLevel
+//	    type: InternalError
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Darevski/go-custom-errors/analysis/migrate"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to YAML or JSON config with rules of packages")
+	write := flag.Bool("w", false, "write changes to files instead of printing diff")
+	flag.Parse()
+
+	if err := run(*configPath, *write, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "errmigrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, write bool, patterns []string) error {
+	var config migrate.Config
+	if configPath != "" {
+		var err error
+		if config, err = migrate.LoadConfig(configPath); err != nil {
+			return err
+		}
+	}
+	rewriter, err := migrate.New(config)
+	if err != nil {
+		return err
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	files, err := goFiles(patterns)
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		if err = rewriteFile(rewriter, path, write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteFile rewrites file and writes it or prints diff
+func rewriteFile(rewriter *migrate.Rewriter, path string, write bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	pkgPath, err := packagePath(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	result, diagnostics, err := rewriter.RewriteSource(path, src, pkgPath)
+	if err != nil {
+		return err
+	}
+	for _, v := range diagnostics {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	if string(result) == string(src) {
+		return nil
+	}
+	if write {
+		return os.WriteFile(path, result, 0o644)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(src)),
+		B:        difflib.SplitLines(string(result)),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+// goFiles returns Go files of patterns, pattern is a file, a directory or a directory with "/..." suffix.
+// Vendor, testdata and hidden directories are skipped
+func goFiles(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		root := strings.TrimSuffix(pattern, "/...")
+		recursive := root != pattern
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				name := entry.Name()
+				if path != root && (!recursive || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".go") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// packagePath returns import path of directory resolved by go.mod, directory path is used if there is no go.mod
+func packagePath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		if data, err := os.ReadFile(filepath.Join(current, "go.mod")); err == nil {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(modulePath(data)+"/"+filepath.ToSlash(rel), "/."), nil
+		}
+		if filepath.Dir(current) == current {
+			return filepath.ToSlash(dir), nil
+		}
+	}
+}
+
+// modulePath returns module path of go.mod file
+func modulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
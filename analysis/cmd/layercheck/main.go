@@ -0,0 +1,14 @@
+// Command layercheck checks errors returned from data and controller layers, see analysis/layercheck package.
+//
+//	layercheck -layer "*/repository/*=DataLevel" -layer "*/controller/*=ControllerLevel" -bare "*/service/*" ./...
+//	layercheck -config layers.yaml ./...
+package main
+
+import (
+	"github.com/Darevski/go-custom-errors/analysis/layercheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(layercheck.Analyzer)
+}
@@ -0,0 +1,15 @@
+// Command misusecheck reports discarded errors, mutation of shared errors and wrong format strings
+// of CustomError constructors, see analysis/misusecheck package. Suggested fixes are applied with -fix flag.
+//
+//	misusecheck ./...
+//	misusecheck -fix ./...
+package main
+
+import (
+	"github.com/Darevski/go-custom-errors/analysis/misusecheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(misusecheck.Analyzer)
+}
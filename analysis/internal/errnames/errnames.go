@@ -0,0 +1,73 @@
+// Package errnames contains built-in error levels and types of github.com/Darevski/go-custom-errors.
+// Analyzers use them instead of importing the errors package, so the analysis module could be installed
+// with go install and go run without the errors package of the same version
+package errnames
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level is a value of errors.ErrorLevel
+type Level int
+
+// Built-in levels, they match errors.ErrorLevel constants
+const (
+	DefaultLevel Level = iota
+	DataLevel
+	UseCaseLevel
+	ContainerLevel
+	ControllerLevel
+	TransportLevel
+)
+
+// Levels are names of built-in levels, name index is a level value
+var Levels = []string{"DefaultLevel", "DataLevel", "UseCaseLevel", "ContainerLevel", "ControllerLevel", "TransportLevel"}
+
+// Types are names of built-in error types, name index is a type value
+var Types = []string{
+	"DefaultType", "NotFound", "InvalidArguments", "InternalError", "BadRequest", "AccessDenied", "Unauthorized",
+	"ClientError", "ServerError", "Conflict", "AlreadyExists", "PreconditionFailed", "RateLimited", "Canceled",
+	"Timeout", "Unavailable", "NotImplemented", "DataLoss",
+}
+
+// String returns name of level analogous to errors.ErrorLevel
+func (l Level) String() string {
+	if l >= 0 && int(l) < len(Levels) {
+		return Levels[l]
+	}
+	return "ErrorLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLevel returns level by its name or plain integer code analogous to errors.ParseErrorLevel
+func ParseLevel(s string) (Level, error) {
+	for k, name := range Levels {
+		if strings.EqualFold(name, s) {
+			return Level(k), nil
+		}
+	}
+	if code, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		return Level(code), nil
+	}
+	return DefaultLevel, fmt.Errorf("unknown error level %q", s)
+}
+
+// IsLevel returns true if name is an exact name of built-in level
+func IsLevel(name string) bool {
+	return contains(Levels, name)
+}
+
+// IsType returns true if name is an exact name of built-in error type
+func IsType(name string) bool {
+	return contains(Types, name)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
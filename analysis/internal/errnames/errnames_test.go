@@ -0,0 +1,53 @@
+package errnames
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// TestCatalog checks that names match errgen.yaml of errors package, it is skipped outside of the repository
+func TestCatalog(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "errgen.yaml"))
+	if os.IsNotExist(err) {
+		t.Skip("errgen.yaml of errors package is not found")
+	}
+	assert.NoError(t, err)
+	var catalog struct {
+		Enums []struct {
+			Type   string   `yaml:"type"`
+			Values []string `yaml:"values"`
+		} `yaml:"enums"`
+	}
+	assert.NoError(t, yaml.Unmarshal(data, &catalog))
+
+	enums := make(map[string][]string)
+	for _, v := range catalog.Enums {
+		enums[v.Type] = v.Values
+	}
+	assert.Equal(t, enums["ErrorLevel"], Levels)
+	assert.Equal(t, enums["ErrorType"], Types)
+}
+
+func TestParseLevel(t *testing.T) {
+	assertions := assert.New(t)
+
+	level, err := ParseLevel("datalevel")
+	assertions.NoError(err)
+	assertions.Equal(DataLevel, level)
+	assertions.Equal("DataLevel", level.String())
+
+	level, err = ParseLevel("10")
+	assertions.NoError(err)
+	assertions.Equal("ErrorLevel(10)", level.String())
+
+	_, err = ParseLevel("Unknown")
+	assertions.Error(err)
+	assertions.True(IsLevel("ControllerLevel"))
+	assertions.False(IsLevel("controllerLevel"))
+	assertions.True(IsType("Unavailable"))
+	assertions.False(IsType("UserNotFound"))
+}
@@ -0,0 +1,118 @@
+package layercheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Darevski/go-custom-errors/analysis/internal/errnames"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule assigns layer to packages that match the pattern. Pattern is a package path where * matches any
+// sequence of symbols including "/", it also matches subpackages if it ends with "/*" like in cErrors.SetPackageLevel
+type Rule struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Level is a name of ErrorLevel, e.g. DataLevel or ControllerLevel
+	Level string `json:"level" yaml:"level"`
+	// Bare enables reports of bare fmt.Errorf and errors.New errors returned from package
+	Bare bool `json:"bare" yaml:"bare"`
+}
+
+// Config contains layer assignments of packages
+type Config struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// LoadConfig reads config from .json, .yaml or .yml file
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+	default:
+		err = fmt.Errorf("unsupported config format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return config, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// layer is a compiled rule
+type layer struct {
+	re    *regexp.Regexp
+	level errnames.Level
+	bare  bool
+}
+
+// compile parses levels and patterns of rules
+func (c Config) compile() ([]layer, error) {
+	layers := make([]layer, 0, len(c.Rules))
+	for _, rule := range c.Rules {
+		level := errnames.DefaultLevel
+		if rule.Level != "" {
+			var err error
+			if level, err = errnames.ParseLevel(rule.Level); err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Pattern, err)
+			}
+		}
+		re, err := regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(rule.Pattern), `\*`, ".*") + "$")
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Pattern, err)
+		}
+		layers = append(layers, layer{re: re, level: level, bare: rule.Bare})
+	}
+	return layers, nil
+}
+
+// match returns level of the first matched rule with level and true if any matched rule enables bare errors check
+func match(layers []layer, pkgPath string) (level errnames.Level, bare bool) {
+	for _, l := range layers {
+		if !l.re.MatchString(pkgPath) && !l.re.MatchString(pkgPath+"/") {
+			continue
+		}
+		if level == errnames.DefaultLevel {
+			level = l.level
+		}
+		bare = bare || l.bare
+	}
+	return level, bare
+}
+
+// ruleFlag is a repeatable flag that adds rules, e.g. -layer "*/repository/*=DataLevel"
+type ruleFlag struct {
+	config *Config
+	bare   bool
+}
+
+func (f ruleFlag) String() string {
+	return ""
+}
+
+func (f ruleFlag) Set(value string) error {
+	if f.bare {
+		f.config.Rules = append(f.config.Rules, Rule{Pattern: value, Bare: true})
+		return nil
+	}
+	k := strings.LastIndex(value, "=")
+	if k < 0 {
+		return fmt.Errorf("layer %q must be in pattern=Level form", value)
+	}
+	f.config.Rules = append(f.config.Rules, Rule{Pattern: value[:k], Level: value[k+1:]})
+	return nil
+}
@@ -0,0 +1,306 @@
+// Package layercheck defines an analyzer that checks errors returned from packages of different layers.
+//
+// Layers are assigned to packages by patterns (see Rule):
+//
+//   - functions of DataLevel packages must return CustomErrors created with DataLevel,
+//     bare errors and errors created with other levels are reported
+//   - functions of ControllerLevel packages must wrap errors returned by other packages instead of returning them unchanged
+//   - packages that opt in with Bare flag must not return bare fmt.Errorf, errors.New and github.com/pkg/errors errors
+//
+// Checks are static: errors built by helpers or stored in variables are trusted, levels set by
+// cErrors.SetPackageLevel policies are not taken into account
+package layercheck
+
+import (
+	"flag"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/Darevski/go-custom-errors/analysis/internal/errnames"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// rootPackage is an import path of errors package
+const rootPackage = "github.com/Darevski/go-custom-errors"
+
+const doc = `check errors returned from data and controller layers
+
+Layers are assigned to packages with -config file or -layer flags, e.g. -layer "*/repository/*=DataLevel".
+DataLevel packages must return CustomErrors created with DataLevel, ControllerLevel packages must wrap errors
+of other packages. Packages set with -bare flag must not return bare fmt.Errorf and errors.New errors.`
+
+// Analyzer checks layer rules configured by flags
+var Analyzer = newFlagAnalyzer()
+
+// bareConstructors are functions that create errors without type and level
+var bareConstructors = map[string]bool{
+	"errors.New":                         true,
+	"fmt.Errorf":                         true,
+	"github.com/pkg/errors.New":          true,
+	"github.com/pkg/errors.Errorf":       true,
+	"github.com/pkg/errors.Wrap":         true,
+	"github.com/pkg/errors.Wrapf":        true,
+	"github.com/pkg/errors.WithStack":    true,
+	"github.com/pkg/errors.WithMessage":  true,
+	"github.com/pkg/errors.WithMessagef": true,
+}
+
+// New create analyzer with layer rules
+func New(config Config) (*analysis.Analyzer, error) {
+	layers, err := config.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &analysis.Analyzer{
+		Name: "layercheck",
+		Doc:  doc,
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return run(pass, layers)
+		},
+	}, nil
+}
+
+func newFlagAnalyzer() *analysis.Analyzer {
+	var config Config
+	var configPath string
+	analyzer := &analysis.Analyzer{
+		Name:  "layercheck",
+		Doc:   doc,
+		Flags: *flag.NewFlagSet("layercheck", flag.ExitOnError),
+	}
+	analyzer.Flags.StringVar(&configPath, "config", "", "path to YAML or JSON config with layer rules")
+	analyzer.Flags.Var(ruleFlag{config: &config}, "layer", "layer of packages in pattern=Level form, could be repeated")
+	analyzer.Flags.Var(ruleFlag{config: &config, bare: true}, "bare", "pattern of packages where bare errors are reported, could be repeated")
+	analyzer.Run = func(pass *analysis.Pass) (interface{}, error) {
+		rules := config
+		if configPath != "" {
+			loaded, err := LoadConfig(configPath)
+			if err != nil {
+				return nil, err
+			}
+			rules.Rules = append(loaded.Rules, rules.Rules...)
+		}
+		layers, err := rules.compile()
+		if err != nil {
+			return nil, err
+		}
+		return run(pass, layers)
+	}
+	return analyzer
+}
+
+// checker checks return statements of package functions
+type checker struct {
+	pass  *analysis.Pass
+	level errnames.Level
+	bare  bool
+}
+
+func run(pass *analysis.Pass, layers []layer) (interface{}, error) {
+	c := checker{pass: pass}
+	c.level, c.bare = match(layers, pass.Pkg.Path())
+	if c.level != errnames.DataLevel && c.level != errnames.ControllerLevel && !c.bare {
+		return nil, nil
+	}
+
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.Position(file.Pos()).Filename, "_test.go") {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch fn := n.(type) {
+			case *ast.FuncDecl:
+				if fn.Body != nil {
+					c.checkFunc(fn.Body, pass.TypesInfo.Defs[fn.Name].Type().(*types.Signature))
+				}
+			case *ast.FuncLit:
+				c.checkFunc(fn.Body, pass.TypesInfo.Types[fn].Type.(*types.Signature))
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// checkFunc checks returned errors of function, nested function literals are checked separately
+func (c checker) checkFunc(body *ast.BlockStmt, sig *types.Signature) {
+	results := sig.Results()
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(stmt.Results) == 1 && results.Len() > 1 {
+				// return f() of multi-value function
+				if call, ok := ast.Unparen(stmt.Results[0]).(*ast.CallExpr); ok && c.level == errnames.ControllerLevel {
+					c.checkForwarded(call, call)
+				}
+				return true
+			}
+			for k, result := range stmt.Results {
+				if k < results.Len() && isError(results.At(k).Type()) {
+					c.checkReturn(ast.Unparen(result), body)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// checkReturn checks returned error expression
+func (c checker) checkReturn(expr ast.Expr, body *ast.BlockStmt) {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		fn := callee(c.pass, e)
+		if fn != nil && bareConstructors[fullName(fn)] {
+			c.checkBare(e, fn)
+			return
+		}
+		if c.level == errnames.DataLevel {
+			c.checkDataLevel(e, fn)
+		}
+		if c.level == errnames.ControllerLevel {
+			c.checkForwarded(e, e)
+		}
+	case *ast.Ident:
+		if c.level == errnames.ControllerLevel {
+			c.checkVariable(e, body)
+		}
+	}
+}
+
+// checkBare reports bare errors in data layer and in packages that opt in
+func (c checker) checkBare(call *ast.CallExpr, fn *types.Func) {
+	switch {
+	case c.level == errnames.DataLevel:
+		c.pass.Reportf(call.Pos(), "data layer returns bare %s error, return CustomError created with DataLevel", shortName(fn))
+	case c.bare:
+		c.pass.Reportf(call.Pos(), "bare %s error is returned, use CustomError", shortName(fn))
+	}
+}
+
+// checkDataLevel reports CustomErrors created in data layer with other levels
+func (c checker) checkDataLevel(call *ast.CallExpr, fn *types.Func) {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != rootPackage {
+		return
+	}
+	switch fn.Name() {
+	case "New", "NewF":
+		// level is the first argument of ErrorType methods and the second one of package functions
+		if isMethod(fn) {
+			c.checkLevel(call.Args[0])
+		} else {
+			c.checkLevel(call.Args[1])
+		}
+	case "NewBase", "NewBaseF":
+		c.pass.Reportf(call.Pos(), "error is created without level in data layer, use DataLevel")
+	case "SetLevel":
+		c.checkLevel(call.Args[0])
+	case "Make", "WrapWith":
+		level := c.optionLevel(call)
+		if level == nil && fn.Name() == "Make" {
+			c.pass.Reportf(call.Pos(), "error is created without level in data layer, use WithLevel(DataLevel)")
+		} else if level != nil {
+			c.checkLevel(level)
+		}
+	}
+}
+
+// optionLevel returns argument of WithLevel option of call
+func (c checker) optionLevel(call *ast.CallExpr) ast.Expr {
+	for _, arg := range call.Args {
+		option, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok || len(option.Args) != 1 {
+			continue
+		}
+		if fn := callee(c.pass, option); fn != nil && fullName(fn) == rootPackage+".WithLevel" {
+			return option.Args[0]
+		}
+	}
+	return nil
+}
+
+// checkLevel reports level expression that is not DataLevel
+func (c checker) checkLevel(expr ast.Expr) {
+	tv, ok := c.pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return
+	}
+	if value, exact := constant.Uint64Val(tv.Value); exact && errnames.Level(value) != errnames.DataLevel {
+		c.pass.Reportf(expr.Pos(), "error is created with %s in data layer, use DataLevel", errnames.Level(value))
+	}
+}
+
+// checkForwarded reports call of other package function that is returned unchanged from controller
+func (c checker) checkForwarded(call *ast.CallExpr, report ast.Node) {
+	fn := callee(c.pass, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg() == c.pass.Pkg || fn.Pkg().Path() == rootPackage || bareConstructors[fullName(fn)] {
+		return
+	}
+	c.pass.Reportf(report.Pos(), "error of %s is returned unchanged from controller, wrap it", shortName(fn))
+}
+
+// checkVariable reports local error variable assigned from other package function and returned unchanged
+func (c checker) checkVariable(ident *ast.Ident, body *ast.BlockStmt) {
+	obj, ok := c.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Parent() == c.pass.Pkg.Scope() {
+		return
+	}
+	var source *ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Pos() > ident.Pos() {
+			return true
+		}
+		for k, lhs := range assign.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok || (c.pass.TypesInfo.Defs[id] != obj && c.pass.TypesInfo.Uses[id] != obj) {
+				continue
+			}
+			rhs := assign.Rhs[0]
+			if len(assign.Rhs) == len(assign.Lhs) {
+				rhs = assign.Rhs[k]
+			}
+			source, _ = ast.Unparen(rhs).(*ast.CallExpr)
+		}
+		return true
+	})
+	if source != nil {
+		c.checkForwarded(source, ident)
+	}
+}
+
+// callee returns called function or method
+func callee(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return fn
+}
+
+// fullName returns package path and name of function, e.g. github.com/pkg/errors.Wrap, receiver of methods is not included
+func fullName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// shortName returns package name and name of function, e.g. fmt.Errorf
+func shortName(fn *types.Func) string {
+	if fn.Pkg() == nil || isMethod(fn) {
+		return fn.Name()
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
+
+func isMethod(fn *types.Func) bool {
+	return fn.Type().(*types.Signature).Recv() != nil
+}
+
+// isError returns true for error interface and interfaces that embed it, e.g. CustomError
+func isError(t types.Type) bool {
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.IsInterface(t) && types.Implements(t, errorType)
+}
@@ -0,0 +1,31 @@
+package layercheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analyzer, err := New(Config{Rules: []Rule{
+		{Pattern: "*/repository", Level: "DataLevel"},
+		{Pattern: "*/controller/*", Level: "ControllerLevel"},
+		{Pattern: "*/service/*", Bare: true},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	analysistest.Run(t, analysistest.TestData(), analyzer, "shop/...")
+}
+
+func TestFlags(t *testing.T) {
+	assertions := assert.New(t)
+	analyzer := newFlagAnalyzer()
+	assertions.NoError(analyzer.Flags.Set("layer", "*/repository/*=DataLevel"))
+	assertions.NoError(analyzer.Flags.Set("bare", "*/service/*"))
+	assertions.Error(analyzer.Flags.Set("layer", "*/controller/*"))
+
+	_, err := New(Config{Rules: []Rule{{Pattern: "*", Level: "Upper"}}})
+	assertions.Error(err)
+}
@@ -0,0 +1,47 @@
+// Package errors is a stub of github.com/Darevski/go-custom-errors for analyzer tests
+package errors
+
+type ErrorLevel uint
+
+const (
+	DefaultLevel = ErrorLevel(iota)
+	DataLevel
+	UseCaseLevel
+	ContainerLevel
+	ControllerLevel
+)
+
+type ErrorType uint
+
+const NotFound = ErrorType(1)
+
+type ErrorBaggage map[string]interface{}
+
+type ErrorMessage string
+
+type CustomError interface {
+	error
+	SetLevel(level ErrorLevel) CustomError
+}
+
+type Option func()
+
+func (i ErrorType) New(level ErrorLevel, baggage ErrorBaggage, severity uint, message ErrorMessage) CustomError {
+	return nil
+}
+
+func New(errType ErrorType, level ErrorLevel, baggage ErrorBaggage, severity uint, message ErrorMessage) CustomError {
+	return nil
+}
+
+func (i ErrorType) NewBase(message ErrorMessage) CustomError { return nil }
+
+func Wrap(err error, message ErrorMessage) CustomError { return nil }
+
+func Make(message ErrorMessage, opts ...Option) CustomError { return nil }
+
+func WrapWith(err error, message ErrorMessage, opts ...Option) CustomError { return nil }
+
+func WithLevel(level ErrorLevel) Option { return nil }
+
+func WithType(errType ErrorType) Option { return nil }
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"fmt"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+
+	"shop/repository"
+)
+
+func Handle(id int) error {
+	if id == 0 {
+		_, err := repository.Get(id)
+		return err // want `error of repository.Get is returned unchanged from controller, wrap it`
+	}
+	if id == 1 {
+		if _, err := repository.Get(id); err != nil {
+			return cErrors.Wrap(err, "handle")
+		}
+	}
+	if id == 2 {
+		return validate(id)
+	}
+	return fmt.Errorf("unknown id %d", id)
+}
+
+func Load(id int) (string, error) {
+	return repository.Get(id) // want `error of repository.Get is returned unchanged from controller, wrap it`
+}
+
+func validate(id int) error {
+	err := fmt.Errorf("invalid id %d", id)
+	return err
+}
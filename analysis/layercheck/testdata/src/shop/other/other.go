@@ -0,0 +1,7 @@
+package other
+
+import "errors"
+
+func Do() error {
+	return errors.New("failed")
+}
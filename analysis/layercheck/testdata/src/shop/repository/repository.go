@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+var errClosed = errors.New("closed")
+
+func Get(id int) (string, error) {
+	if id < 0 {
+		return "", fmt.Errorf("invalid id %d", id) // want `data layer returns bare fmt.Errorf error, return CustomError created with DataLevel`
+	}
+	if id == 0 {
+		return "", cErrors.NotFound.New(cErrors.DataLevel, nil, 0, "not found")
+	}
+	if id == 1 {
+		return "", cErrors.NotFound.New(cErrors.UseCaseLevel, nil, 0, "not found") // want `error is created with UseCaseLevel in data layer, use DataLevel`
+	}
+	if id == 2 {
+		return "", cErrors.NotFound.NewBase("not found") // want `error is created without level in data layer, use DataLevel`
+	}
+	if id == 3 {
+		return "", cErrors.Make("not found", cErrors.WithType(cErrors.NotFound)) // want `error is created without level in data layer, use WithLevel\(DataLevel\)`
+	}
+	if id == 4 {
+		return "", cErrors.Make("not found", cErrors.WithLevel(cErrors.DataLevel))
+	}
+	if id == 5 {
+		return "", cErrors.Wrap(errClosed, "get").SetLevel(cErrors.ControllerLevel) // want `error is created with ControllerLevel in data layer, use DataLevel`
+	}
+	if id == 7 {
+		return "", cErrors.New(cErrors.NotFound, cErrors.ControllerLevel, nil, 0, "not found") // want `error is created with ControllerLevel in data layer, use DataLevel`
+	}
+	if id == 6 {
+		return "", cErrors.WrapWith(errClosed, "get")
+	}
+	load := func() error {
+		return errors.New("load") // want `data layer returns bare errors.New error, return CustomError created with DataLevel`
+	}
+	return "", load()
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"errors"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+func Do(ok bool) error {
+	if ok {
+		return cErrors.NotFound.NewBase("not found")
+	}
+	return errors.New("failed") // want `bare errors.New error is returned, use CustomError`
+}
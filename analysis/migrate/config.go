@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Darevski/go-custom-errors/analysis/internal/errnames"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule sets level and type of errors created in packages that match the pattern. Pattern is a package path where
+// * matches any sequence of symbols including "/", it also matches subpackages if it ends with "/*"
+type Rule struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Level is a name of ErrorLevel, e.g. DataLevel
+	Level string `json:"level" yaml:"level"`
+	// Type is a name of ErrorType, e.g. InternalError
+	Type string `json:"type" yaml:"type"`
+}
+
+// Config contains rules of packages, the first matched rule is applied
+type Config struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// LoadConfig reads config from .json, .yaml or .yml file
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+	default:
+		err = fmt.Errorf("unsupported config format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return config, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// rule is a compiled rule, empty level and type are not added
+type rule struct {
+	re        *regexp.Regexp
+	level     string
+	errorType string
+}
+
+// compile checks levels and types of rules and compiles patterns
+func (c Config) compile() ([]rule, error) {
+	rules := make([]rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		if r.Level != "" {
+			if !errnames.IsLevel(r.Level) {
+				return nil, fmt.Errorf("rule %q: unknown level %q", r.Pattern, r.Level)
+			}
+		}
+		if r.Type != "" {
+			if !errnames.IsType(r.Type) {
+				return nil, fmt.Errorf("rule %q: unknown type %q", r.Pattern, r.Type)
+			}
+		}
+		re, err := regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(r.Pattern), `\*`, ".*") + "$")
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.Pattern, err)
+		}
+		rules = append(rules, rule{re: re, level: r.Level, errorType: r.Type})
+	}
+	return rules, nil
+}
+
+// match returns the first rule that matches package
+func match(rules []rule, pkgPath string) rule {
+	for _, r := range rules {
+		if r.re.MatchString(pkgPath) || r.re.MatchString(pkgPath+"/") {
+			return r
+		}
+	}
+	return rule{}
+}
@@ -0,0 +1,383 @@
+// Package migrate rewrites errors created with github.com/pkg/errors and fmt.Errorf("...: %w", err) into CustomErrors:
+//
+//	errors.New("closed")                   -> cErrors.NewBase("closed")
+//	errors.Errorf("user %d", id)           -> cErrors.NewBaseF("user %d", id)
+//	errors.Wrap(err, "load")               -> cErrors.Wrap(err, "load")
+//	errors.Wrapf(err, "load %d", id)       -> cErrors.WrapF(err, "load %d", id)
+//	fmt.Errorf("load %d: %w", id, err)     -> cErrors.WrapF(err, "load %d", id)
+//
+// Package-level errors.New variables are compared by identity, so they become sentinels with code that consists of
+// package path and variable name:
+//
+//	var ErrClosed = errors.New("closed")   -> var ErrClosed = cErrors.NewSentinel("github.com/acme/shop/users.ErrClosed", cErrors.DefaultType, "closed")
+//
+// errors.Wrap and errors.Wrapf return nil for nil error unlike cErrors.Wrap and cErrors.WrapF, so they are rewritten only
+// inside `if err != nil` (or else branch of `if err == nil`), other calls are kept and reported as diagnostics.
+//
+// Rules of Config add error type (cErrors.NotFound.NewBase(...)) and level (.SetLevel(cErrors.DataLevel))
+// to errors of matched packages. Imports are fixed up, unused github.com/pkg/errors and fmt imports are removed
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const (
+	// rootPackage is an import path of errors package
+	rootPackage = "github.com/Darevski/go-custom-errors"
+	// pkgErrors is an import path of github.com/pkg/errors package
+	pkgErrors = "github.com/pkg/errors"
+	// rootName is a name of errors package import added by rewriter
+	rootName = "cErrors"
+)
+
+// replacements are names of constructors that replace github.com/pkg/errors functions
+var replacements = map[string]string{
+	"New":    "NewBase",
+	"Errorf": "NewBaseF",
+	"Wrap":   "Wrap",
+	"Wrapf":  "WrapF",
+}
+
+// Diagnostic is a call that could not be rewritten safely
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Pos, d.Message)
+}
+
+// Rewriter rewrites files of packages
+type Rewriter struct {
+	rules []rule
+}
+
+// New create rewriter with rules of packages
+func New(config Config) (*Rewriter, error) {
+	rules, err := config.compile()
+	if err != nil {
+		return nil, err
+	}
+	return &Rewriter{rules: rules}, nil
+}
+
+// RewriteSource rewrites source of file that belongs to package, source is returned unchanged if there is nothing to rewrite.
+// Calls that are kept because they could not be rewritten safely are returned as diagnostics
+func (r *Rewriter) RewriteSource(filename string, src []byte, pkgPath string) ([]byte, []Diagnostic, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	changed, diagnostics := r.Rewrite(fset, file, pkgPath)
+	if !changed {
+		return src, diagnostics, nil
+	}
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, file); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), diagnostics, nil
+}
+
+// Rewrite rewrites calls of file that belongs to package and fixes imports, it returns true if file is changed
+// and diagnostics of calls that are kept
+func (r *Rewriter) Rewrite(fset *token.FileSet, file *ast.File, pkgPath string) (bool, []Diagnostic) {
+	pkgErrorsName := importName(file, pkgErrors)
+	fmtName := importName(file, "fmt")
+	if pkgErrorsName == "" && fmtName == "" {
+		return false, nil
+	}
+	name := importName(file, rootPackage)
+	if name == "" {
+		name = rootName
+	}
+	rw := rewrite{rule: match(r.rules, pkgPath), root: name, pkgPath: pkgPath}
+	guarded := guardedCalls(file)
+	sentinels := packageVars(file)
+
+	var diagnostics []Diagnostic
+	report := func(node ast.Node, format string, args ...interface{}) {
+		diagnostics = append(diagnostics, Diagnostic{Pos: fset.Position(node.Pos()), Message: fmt.Sprintf(format, args...)})
+	}
+
+	changed := false
+	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		call, ok := c.Node().(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fn, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isPackage(fn.X, pkgErrorsName, fmtName) {
+			return true
+		}
+		var result ast.Expr
+		if fn.X.(*ast.Ident).Name == pkgErrorsName {
+			varName, isVar := sentinels[call]
+			switch {
+			case isVar && fn.Sel.Name == "New":
+				result = rw.sentinel(varName, call)
+			case isVar && replacements[fn.Sel.Name] != "":
+				report(call, "package-level %s.%s is kept: it is compared by identity, use cErrors.NewSentinel", pkgErrorsName, fn.Sel.Name)
+			case (fn.Sel.Name == "Wrap" || fn.Sel.Name == "Wrapf") && !guarded[call]:
+				report(call, "%s.%s is kept: it returns nil for nil error unlike cErrors.Wrap, check error for nil before wrapping",
+					pkgErrorsName, fn.Sel.Name)
+			default:
+				result = rw.pkgErrors(fn.Sel.Name, call)
+			}
+		} else if fn.Sel.Name == "Errorf" {
+			result = rw.errorf(call)
+		}
+		if result != nil {
+			c.Replace(result)
+			changed = true
+		}
+		return true
+	})
+	if !changed {
+		return false, diagnostics
+	}
+
+	if importName(file, rootPackage) == "" {
+		astutil.AddNamedImport(fset, file, rootName, rootPackage)
+	}
+	var unused []*ast.ImportSpec
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if (path == pkgErrors || path == "fmt") && !astutil.UsesImport(file, path) {
+			unused = append(unused, spec)
+		}
+	}
+	for _, spec := range unused {
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		path, _ := strconv.Unquote(spec.Path.Value)
+		astutil.DeleteNamedImport(fset, file, name, path)
+	}
+	// single import is written without parentheses
+	if len(file.Imports) == 1 {
+		if decl, ok := file.Decls[0].(*ast.GenDecl); ok && decl.Tok == token.IMPORT && len(decl.Specs) == 1 {
+			decl.Lparen, decl.Rparen = token.NoPos, token.NoPos
+		}
+	}
+	return true, diagnostics
+}
+
+// guardedCalls returns calls whose first argument is checked for nil: calls inside body of `if err != nil`
+// or inside else branch of `if err == nil`, conditions could be joined by && and || respectively
+func guardedCalls(file *ast.File) map[*ast.CallExpr]bool {
+	result := make(map[*ast.CallExpr]bool)
+	var stack []ast.Node
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if call, ok := node.(*ast.CallExpr); ok && len(call.Args) > 0 {
+			arg := types.ExprString(call.Args[0])
+			for k := len(stack) - 1; k > 0; k-- {
+				ifStmt, ok := stack[k-1].(*ast.IfStmt)
+				if !ok {
+					continue
+				}
+				if (stack[k] == ifStmt.Body && checksNil(ifStmt.Cond, arg, token.NEQ, token.LAND)) ||
+					(stack[k] == ifStmt.Else && checksNil(ifStmt.Cond, arg, token.EQL, token.LOR)) {
+					result[call] = true
+					break
+				}
+			}
+		}
+		stack = append(stack, node)
+		return true
+	})
+	return result
+}
+
+// checksNil returns true if condition contains `expr <op> nil` comparison joined with other conditions by join operator
+func checksNil(cond ast.Expr, expr string, op, join token.Token) bool {
+	switch cond := cond.(type) {
+	case *ast.ParenExpr:
+		return checksNil(cond.X, expr, op, join)
+	case *ast.BinaryExpr:
+		if cond.Op == join {
+			return checksNil(cond.X, expr, op, join) || checksNil(cond.Y, expr, op, join)
+		}
+		if cond.Op != op {
+			return false
+		}
+		return (isNil(cond.Y) && types.ExprString(cond.X) == expr) || (isNil(cond.X) && types.ExprString(cond.Y) == expr)
+	}
+	return false
+}
+
+func isNil(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// packageVars returns calls that initialize package-level variables with names of variables
+func packageVars(file *ast.File) map[*ast.CallExpr]string {
+	result := make(map[*ast.CallExpr]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if len(value.Names) != len(value.Values) {
+				continue
+			}
+			for k, v := range value.Values {
+				if call, ok := v.(*ast.CallExpr); ok {
+					result[call] = value.Names[k].Name
+				}
+			}
+		}
+	}
+	return result
+}
+
+// rewrite builds replacement calls
+type rewrite struct {
+	rule    rule
+	root    string
+	pkgPath string
+}
+
+// sentinel returns sentinel constructor call that replaces errors.New of package-level variable,
+// type and level of the rule are passed as arguments
+func (rw rewrite) sentinel(varName string, call *ast.CallExpr) ast.Expr {
+	if len(call.Args) != 1 {
+		return nil
+	}
+	errorType := rw.rule.errorType
+	if errorType == "" {
+		errorType = "DefaultType"
+	}
+	// new nodes take position of the call, otherwise printer breaks lines between them and the original argument
+	pos := call.Pos()
+	ident := func(name string) *ast.Ident { return &ast.Ident{Name: name, NamePos: pos} }
+	args := []ast.Expr{
+		&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(rw.pkgPath + "." + varName), ValuePos: pos},
+		&ast.SelectorExpr{X: ident(rw.root), Sel: ident(errorType)},
+		call.Args[0],
+	}
+	if rw.rule.level != "" {
+		args = append(args, &ast.CallExpr{
+			Fun:    &ast.SelectorExpr{X: ident(rw.root), Sel: ident("WithLevel")},
+			Args:   []ast.Expr{&ast.SelectorExpr{X: ident(rw.root), Sel: ident(rw.rule.level)}},
+			Lparen: call.Rparen,
+			Rparen: call.Rparen,
+		})
+	}
+	return &ast.CallExpr{Fun: &ast.SelectorExpr{X: ident(rw.root), Sel: ident("NewSentinel")}, Args: args, Lparen: call.Lparen, Rparen: call.Rparen}
+}
+
+// pkgErrors returns replacement of github.com/pkg/errors function call or nil
+func (rw rewrite) pkgErrors(name string, call *ast.CallExpr) ast.Expr {
+	replacement, ok := replacements[name]
+	if !ok {
+		return nil
+	}
+	return rw.call(replacement, call.Args, call.Ellipsis)
+}
+
+// errorf returns replacement of fmt.Errorf("...: %w", ..., err) call or nil, format must be a string literal
+// that ends with ": %w" and contains no other %w verbs or explicit argument indexes
+func (rw rewrite) errorf(call *ast.CallExpr) ast.Expr {
+	if len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return nil
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil
+	}
+	format, err := strconv.Unquote(lit.Value)
+	if err != nil || !strings.HasSuffix(format, ": %w") {
+		return nil
+	}
+	message := strings.TrimSuffix(format, ": %w")
+	if strings.Contains(message, "%w") || strings.Contains(message, "%[") {
+		return nil
+	}
+
+	wrapped := call.Args[len(call.Args)-1]
+	args := call.Args[1 : len(call.Args)-1]
+	if len(args) == 0 {
+		message = strings.ReplaceAll(message, "%%", "%")
+		return rw.call("Wrap", []ast.Expr{wrapped, stringLit(message)}, token.NoPos)
+	}
+	return rw.call("WrapF", append([]ast.Expr{wrapped, stringLit(message)}, args...), token.NoPos)
+}
+
+// call returns constructor call with type and level of the rule
+func (rw rewrite) call(name string, args []ast.Expr, ellipsis token.Pos) ast.Expr {
+	var fn ast.Expr = &ast.SelectorExpr{X: ast.NewIdent(rw.root), Sel: ast.NewIdent(name)}
+	if rw.rule.errorType != "" {
+		fn = &ast.SelectorExpr{
+			X:   &ast.SelectorExpr{X: ast.NewIdent(rw.root), Sel: ast.NewIdent(rw.rule.errorType)},
+			Sel: ast.NewIdent(name),
+		}
+	}
+	var result ast.Expr = &ast.CallExpr{Fun: fn, Args: args, Ellipsis: ellipsis}
+	if rw.rule.level != "" {
+		result = &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: result, Sel: ast.NewIdent("SetLevel")},
+			Args: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent(rw.root), Sel: ast.NewIdent(rw.rule.level)}},
+		}
+	}
+	return result
+}
+
+// importName returns name of imported package in file or empty string if it is not imported
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if value, _ := strconv.Unquote(spec.Path.Value); value != path {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return ""
+			}
+			return spec.Name.Name
+		}
+		if path == pkgErrors || path == rootPackage {
+			return "errors"
+		}
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	return ""
+}
+
+// isPackage returns true if expression is an unresolved identifier of one of packages
+func isPackage(expr ast.Expr, names ...string) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident.Obj != nil {
+		return false
+	}
+	for _, name := range names {
+		if name != "" && ident.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func stringLit(s string) *ast.BasicLit {
+	return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(s)}
+}
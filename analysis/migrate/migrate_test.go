@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRewriteSource(t *testing.T) {
+	rewriter, err := New(Config{Rules: []Rule{
+		{Pattern: "*/repository/*", Level: "DataLevel", Type: "InternalError"},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	packages := map[string]string{"config": "github.com/acme/shop/repository/orders"}
+
+	inputs, _ := filepath.Glob("testdata/*.input")
+	assert.NotEmpty(t, inputs)
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".input")
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(input)
+			assert.NoError(t, err)
+			pkgPath := packages[name]
+			if pkgPath == "" {
+				pkgPath = "github.com/acme/shop/users"
+			}
+
+			result, diagnostics, err := rewriter.RewriteSource(input, src, pkgPath)
+			if !assert.NoError(t, err) {
+				return
+			}
+			golden := strings.TrimSuffix(input, ".input") + ".golden"
+			var messages strings.Builder
+			for _, v := range diagnostics {
+				fmt.Fprintf(&messages, "%d:%d: %s\n", v.Pos.Line, v.Pos.Column, v.Message)
+			}
+			goldenDiagnostics := strings.TrimSuffix(input, ".input") + ".diagnostics"
+			if *update {
+				assert.NoError(t, os.WriteFile(golden, result, 0o644))
+				if len(diagnostics) > 0 {
+					assert.NoError(t, os.WriteFile(goldenDiagnostics, []byte(messages.String()), 0o644))
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(result))
+			expected, _ = os.ReadFile(goldenDiagnostics)
+			assert.Equal(t, string(expected), messages.String(), "Check diagnostics")
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	_, err := New(Config{Rules: []Rule{{Pattern: "*", Level: "Upper"}}})
+	assert.Error(t, err)
+	_, err = New(Config{Rules: []Rule{{Pattern: "*", Type: "Missing"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package misusecheck
+
+import (
+	"go/types"
+	"strings"
+)
+
+// parseFormat returns verbs of format in order of arguments, '*' is returned for width and precision arguments.
+// Formats with explicit argument indexes are not checked, indexed is true for them
+func parseFormat(format string) (verbs []rune, indexed bool, err string) {
+	runes := []rune(format)
+	for k := 0; k < len(runes); k++ {
+		if runes[k] != '%' {
+			continue
+		}
+		k++
+		for k < len(runes) && strings.ContainsRune("+-# 0", runes[k]) {
+			k++
+		}
+		for k < len(runes) && (runes[k] == '*' || runes[k] == '.' || (runes[k] >= '0' && runes[k] <= '9')) {
+			if runes[k] == '*' {
+				verbs = append(verbs, '*')
+			}
+			k++
+		}
+		if k == len(runes) {
+			return nil, false, "ends with incomplete verb"
+		}
+		switch runes[k] {
+		case '%':
+		case '[':
+			return nil, true, ""
+		default:
+			verbs = append(verbs, runes[k])
+		}
+	}
+	return verbs, false, ""
+}
+
+// matchesVerb returns false if basic type of argument can not be formatted by verb,
+// types with String or Error methods and non-basic types are not checked
+func matchesVerb(verb rune, t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok || hasMethod(t, "String") || hasMethod(t, "Error") {
+		return true
+	}
+	info := basic.Info()
+	isInt := info&types.IsInteger != 0
+	isFloat := info&(types.IsFloat|types.IsComplex) != 0
+	isString := info&types.IsString != 0
+	switch verb {
+	case '*', 'c', 'd', 'o', 'O', 'U':
+		return isInt
+	case 'b':
+		return isInt || isFloat
+	case 'x', 'X':
+		return isInt || isFloat || isString
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return isFloat
+	case 's':
+		return isString
+	case 'q':
+		return isString || isInt
+	case 't':
+		return info&types.IsBoolean != 0
+	}
+	return true
+}
+
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
@@ -0,0 +1,236 @@
+// Package misusecheck defines an analyzer that reports misuse of CustomError constructors and setters:
+//
+//   - results of Wrap, WrapF, WrapWith, New, NewF, NewBase, NewBaseF and Make are discarded
+//   - package-level CustomError variables (e.g. sentinels) are changed by SetSeverity, SetLevel, AddBaggage,
+//     SetBaggage, SetPublicMessage or SetPublicCode outside of init functions
+//   - format strings of NewF, NewBaseF and WrapF do not match their arguments
+//
+// Discarded Wrap results and constant format strings without arguments have suggested fixes
+package misusecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// rootPackage is an import path of errors package
+const rootPackage = "github.com/Darevski/go-custom-errors"
+
+// Analyzer reports misuse of CustomError constructors and setters
+var Analyzer = &analysis.Analyzer{
+	Name:     "misusecheck",
+	Doc:      "report discarded errors, mutation of shared errors and wrong format strings of CustomError constructors",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// constructors are functions and ErrorType methods that create errors
+var constructors = map[string]bool{
+	"New": true, "NewF": true, "NewBase": true, "NewBaseF": true,
+	"Wrap": true, "WrapF": true, "WrapWith": true, "Make": true,
+}
+
+// setters are CustomError methods that change the receiver
+var setters = map[string]bool{
+	"SetSeverity": true, "SetLevel": true, "AddBaggage": true, "SetBaggage": true,
+	"SetPublicMessage": true, "SetPublicCode": true,
+}
+
+// unformatted are names of constructors with constant message that replace formatting ones
+var unformatted = map[string]string{"NewF": "New", "NewBaseF": "NewBase", "WrapF": "Wrap"}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{(*ast.ExprStmt)(nil), (*ast.CallExpr)(nil)}
+	inspect.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch node := n.(type) {
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(node.X).(*ast.CallExpr); ok {
+				checkDiscarded(pass, node, call)
+			}
+		case *ast.CallExpr:
+			fn := rootFunc(pass, node)
+			if fn == nil {
+				return true
+			}
+			if setters[fn.Name()] {
+				checkMutation(pass, node, fn, stack)
+			}
+			if position, ok := formatPosition(fn); ok {
+				checkFormat(pass, node, fn, position)
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkDiscarded reports constructors used as statements, the result of Wrap is suggested to be assigned to the wrapped variable
+func checkDiscarded(pass *analysis.Pass, stmt *ast.ExprStmt, call *ast.CallExpr) {
+	fn := rootFunc(pass, call)
+	if fn == nil || !constructors[fn.Name()] {
+		return
+	}
+	if !strings.HasPrefix(fn.Name(), "Wrap") {
+		pass.Reportf(call.Pos(), "result of %s is discarded, created error is lost", fn.Name())
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "result of " + fn.Name() + " is discarded, wrapped error is lost",
+	}
+	if target, ok := ast.Unparen(call.Args[0]).(*ast.Ident); ok && isAssignable(pass, target, fn) {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Assign result to " + target.Name,
+			TextEdits: []analysis.TextEdit{{Pos: stmt.Pos(), End: stmt.Pos(), NewText: []byte(target.Name + " = ")}},
+		}}
+	}
+	pass.Report(diagnostic)
+}
+
+// isAssignable returns true if result of function could be assigned to variable
+func isAssignable(pass *analysis.Pass, ident *ast.Ident, fn *types.Func) bool {
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	result := fn.Type().(*types.Signature).Results().At(0).Type()
+	return ok && types.AssignableTo(result, obj.Type())
+}
+
+// checkMutation reports setters called on package-level variables outside of init functions of their package
+func checkMutation(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, stack []ast.Node) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	var ident *ast.Ident
+	switch receiver := ast.Unparen(selector.X).(type) {
+	case *ast.Ident:
+		ident = receiver
+	case *ast.SelectorExpr:
+		ident = receiver.Sel
+	default:
+		return
+	}
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return
+	}
+	if obj.Pkg() == pass.Pkg && inInit(stack) {
+		return
+	}
+	pass.Reportf(call.Pos(), "%s changes package-level error %s shared by all callers, wrap it before changing", fn.Name(), obj.Name())
+}
+
+// inInit returns true if node is inside init function
+func inInit(stack []ast.Node) bool {
+	for _, n := range stack {
+		if decl, ok := n.(*ast.FuncDecl); ok && decl.Recv == nil && decl.Name.Name == "init" {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPosition returns position of format argument of formatting constructor
+func formatPosition(fn *types.Func) (int, bool) {
+	switch fn.Name() {
+	case "NewBaseF":
+		return 0, true
+	case "WrapF":
+		return 1, true
+	case "NewF":
+		if isMethod(fn) {
+			return 3, true
+		}
+		return 4, true
+	}
+	return 0, false
+}
+
+// checkFormat reports format strings that do not match arguments, it is skipped for non-constant formats,
+// formats with explicit argument indexes and calls with spread arguments
+func checkFormat(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, position int) {
+	if len(call.Args) <= position || call.Ellipsis.IsValid() {
+		return
+	}
+	tv := pass.TypesInfo.Types[call.Args[position]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	format := constant.StringVal(tv.Value)
+	args := call.Args[position+1:]
+
+	verbs, indexed, err := parseFormat(format)
+	if err != "" {
+		pass.Reportf(call.Args[position].Pos(), "%s format %q %s", fn.Name(), format, err)
+		return
+	}
+	if indexed {
+		return
+	}
+	if verbs == nil && len(args) == 0 && !strings.Contains(format, "%") {
+		suggestUnformatted(pass, call, fn, format)
+		return
+	}
+	for _, v := range verbs {
+		if v == 'w' {
+			pass.Reportf(call.Args[position].Pos(), "%s does not support %%w verb, use WrapF to wrap errors", fn.Name())
+			return
+		}
+	}
+	if len(verbs) != len(args) {
+		pass.Reportf(call.Pos(), "%s format %q reads %d args, but call has %d args", fn.Name(), format, len(verbs), len(args))
+		return
+	}
+	for k, v := range verbs {
+		if t := pass.TypesInfo.TypeOf(args[k]); t != nil && !matchesVerb(v, t) {
+			pass.Reportf(args[k].Pos(), "%s format %%%c has arg %s of wrong type %s", fn.Name(), v, types.ExprString(args[k]), t)
+		}
+	}
+}
+
+// suggestUnformatted reports formatting constructor without arguments and suggests constructor with constant message
+func suggestUnformatted(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, format string) {
+	replacement := unformatted[fn.Name()]
+	diagnostic := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fn.Name() + " is called without formatting arguments, use " + replacement,
+	}
+	if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace " + fn.Name() + " with " + replacement,
+			TextEdits: []analysis.TextEdit{{Pos: selector.Sel.Pos(), End: selector.Sel.End(), NewText: []byte(replacement)}},
+		}}
+	} else if ident, ok := call.Fun.(*ast.Ident); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace " + fn.Name() + " with " + replacement,
+			TextEdits: []analysis.TextEdit{{Pos: ident.Pos(), End: ident.End(), NewText: []byte(replacement)}},
+		}}
+	}
+	pass.Report(diagnostic)
+}
+
+// rootFunc returns called function or method of errors package
+func rootFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != rootPackage {
+		return nil
+	}
+	return fn
+}
+
+func isMethod(fn *types.Func) bool {
+	return fn.Type().(*types.Signature).Recv() != nil
+}
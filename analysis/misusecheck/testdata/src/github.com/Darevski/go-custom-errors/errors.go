@@ -0,0 +1,51 @@
+// Package errors is a stub of github.com/Darevski/go-custom-errors for analyzer tests
+package errors
+
+type ErrorLevel uint
+
+type ErrorSeverity uint
+
+const DataLevel = ErrorLevel(1)
+
+type ErrorType uint
+
+const NotFound = ErrorType(1)
+
+type ErrorBaggage map[string]interface{}
+
+type ErrorMessage string
+
+type CustomError interface {
+	error
+	SetLevel(level ErrorLevel) CustomError
+	SetSeverity(severity ErrorSeverity) CustomError
+	AddBaggage(baggage ErrorBaggage) CustomError
+}
+
+type Option func()
+
+func New(errType ErrorType, level ErrorLevel, baggage ErrorBaggage, severity ErrorSeverity, message ErrorMessage) CustomError {
+	return nil
+}
+
+func NewF(errType ErrorType, level ErrorLevel, baggage ErrorBaggage, severity ErrorSeverity, format string, args ...interface{}) CustomError {
+	return nil
+}
+
+func NewBase(message ErrorMessage) CustomError { return nil }
+
+func NewBaseF(format string, args ...interface{}) CustomError { return nil }
+
+func (i ErrorType) NewBase(message ErrorMessage) CustomError { return nil }
+
+func (i ErrorType) NewBaseF(format string, args ...interface{}) CustomError { return nil }
+
+func Wrap(err error, message ErrorMessage) CustomError { return nil }
+
+func WrapF(err error, format string, args ...interface{}) CustomError { return nil }
+
+func (i ErrorType) WrapF(err error, format string, args ...interface{}) CustomError { return nil }
+
+func Make(message ErrorMessage, opts ...Option) CustomError { return nil }
+
+func NewSentinel(code string, errType ErrorType, message ErrorMessage) CustomError { return nil }
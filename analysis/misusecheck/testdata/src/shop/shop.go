@@ -0,0 +1,53 @@
+package shop
+
+import (
+	"errors"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+
+	"shop/users"
+)
+
+var errClosed = cErrors.NewSentinel("shop.closed", cErrors.NotFound, "shop is closed")
+
+func init() {
+	errClosed.SetLevel(cErrors.DataLevel)
+}
+
+type id int
+
+func (i id) String() string { return "id" }
+
+func Discarded(err error) error {
+	cErrors.Wrap(err, "load") // want `result of Wrap is discarded, wrapped error is lost`
+	if err != nil {
+		cErrors.WrapF(err, "load %d", 42) // want `result of WrapF is discarded, wrapped error is lost`
+	}
+	cErrors.Make("failed")                // want `result of Make is discarded, created error is lost`
+	cErrors.Wrap(errors.New("x"), "load") // want `result of Wrap is discarded, wrapped error is lost`
+	return err
+}
+
+func Mutation(userID int) error {
+	errClosed.AddBaggage(cErrors.ErrorBaggage{"userID": userID}) // want `AddBaggage changes package-level error errClosed shared by all callers, wrap it before changing`
+	users.ErrNotFound.SetSeverity(1)                             // want `SetSeverity changes package-level error ErrNotFound shared by all callers, wrap it before changing`
+	err := cErrors.Wrap(users.ErrNotFound, "load")
+	err.AddBaggage(cErrors.ErrorBaggage{"userID": userID})
+	return err
+}
+
+func Format(err error, name string, count int, delay time.Duration) {
+	_ = cErrors.NewBaseF("user %s has %d orders", name, count)
+	_ = cErrors.NewBaseF("user %s has %d orders", name) // want `NewBaseF format "user %s has %d orders" reads 2 args, but call has 1 args`
+	_ = cErrors.NewBaseF("user %d", name)               // want `NewBaseF format %d has arg name of wrong type string`
+	_ = cErrors.NewBaseF("%s", id(1))
+	_ = cErrors.NewBaseF("delay %v, %[1]s", delay)
+	_ = cErrors.NewBaseF("100%% done")
+	_ = cErrors.NewBaseF("wrapped %w", err)                           // want `NewBaseF does not support %w verb, use WrapF to wrap errors`
+	_ = cErrors.NewBaseF("incomplete %")                              // want `NewBaseF format "incomplete %" ends with incomplete verb`
+	_ = cErrors.NotFound.WrapF(err, "load %s %d", name)               // want `WrapF format "load %s %d" reads 2 args, but call has 1 args`
+	_ = cErrors.NewF(cErrors.NotFound, 0, nil, 0, "%*d", count, name) // want `NewF format %d has arg name of wrong type string`
+	_ = cErrors.WrapF(err, "load user")                               // want `WrapF is called without formatting arguments, use Wrap`
+	_ = cErrors.NotFound.NewBaseF("not found")                        // want `NewBaseF is called without formatting arguments, use NewBase`
+}
@@ -0,0 +1,5 @@
+package users
+
+import cErrors "github.com/Darevski/go-custom-errors"
+
+var ErrNotFound = cErrors.NewSentinel("user.not_found", cErrors.NotFound, "user not found")
@@ -0,0 +1,295 @@
+// Package breaker implements circuit breaker that takes classification of custom errors into account.
+//
+// Only errors of configured types and severities are counted as failures, so NotFound or InvalidArguments
+// errors do not open the circuit while InternalError or Timeout errors do. When the circuit is open
+// calls are rejected with error that matches ErrOpen and carries the last counted error in its chain
+//
+//	cb := breaker.New(breaker.Settings{Name: "users"})
+//	err := cb.Do(func() error {
+//		return client.Call(ctx)
+//	})
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Default settings values
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+// ErrOpen is a sentinel of errors returned by open circuit breaker
+var ErrOpen = cErrors.NewSentinel("breaker.open", cErrors.Unavailable, "circuit breaker is open")
+
+// State is a state of circuit breaker
+type State int
+
+const (
+	// Closed state allows all calls
+	Closed State = iota
+	// Open state rejects all calls
+	Open
+	// HalfOpen state allows limited count of probe calls
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Clock is a source of current time, it could be replaced in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is Clock based on time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Settings of circuit breaker, zero values are replaced with default ones
+type Settings struct {
+	// Name of breaker is passed into OnStateChange and errors baggage
+	Name string
+	// Types are counted error types including their descendant types, ServerError category is used by default
+	Types []cErrors.ErrorType
+	// Severities are counted error severities, errors of any severity are counted if it is empty.
+	// Error is counted only if it matches both Types and Severities
+	Severities []cErrors.ErrorSeverity
+	// CountNative makes errors that are not CustomError counted as failures
+	CountNative bool
+	// CountOpen makes errors of open circuit breakers (matching ErrOpen) counted as failures, e.g. when
+	// the call is guarded by the other breaker. They are not counted by default even though they are Unavailable
+	CountOpen bool
+	// FailureThreshold is a count of consecutive counted failures that opens the circuit
+	FailureThreshold int
+	// OpenTimeout is a duration of open state before probing
+	OpenTimeout time.Duration
+	// MaxProbes is a max count of concurrent calls in half-open state, 1 by default
+	MaxProbes int
+	// SuccessThreshold is a count of successful probes that closes the circuit, 1 by default
+	SuccessThreshold int
+	// OnStateChange is called on every state change
+	OnStateChange func(name string, from, to State)
+	// Clock is used to measure open state duration
+	Clock Clock
+}
+
+// Breaker is a circuit breaker, it is safe for concurrent use
+type Breaker struct {
+	settings Settings
+	counts   cErrors.Matcher
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	failures   int
+	successes  int
+	probes     int
+	openedAt   time.Time
+	lastErr    error
+	// changes are state changes that have not been passed into OnStateChange yet
+	changes []stateChange
+}
+
+// stateChange describes single state change
+type stateChange struct {
+	from, to State
+}
+
+// New create circuit breaker in closed state
+func New(settings Settings) *Breaker {
+	if len(settings.Types) == 0 {
+		settings.Types = []cErrors.ErrorType{cErrors.ServerError}
+	}
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultFailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = DefaultOpenTimeout
+	}
+	if settings.MaxProbes <= 0 {
+		settings.MaxProbes = 1
+	}
+	if settings.SuccessThreshold <= 0 {
+		settings.SuccessThreshold = 1
+	}
+	if settings.Clock == nil {
+		settings.Clock = realClock{}
+	}
+
+	counts := cErrors.HasType(settings.Types...)
+	if len(settings.Severities) > 0 {
+		counts = cErrors.All(counts, cErrors.HasSeverity(settings.Severities...))
+	}
+	return &Breaker{settings: settings, counts: counts}
+}
+
+// State returns current state of circuit breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.unlock()
+	b.refresh(b.settings.Clock.Now())
+	return b.state
+}
+
+// Do calls fn if circuit allows it and records its result. Error matching ErrOpen is returned without fn calling
+// if circuit is open or there are too many probes in half-open state. Panic of fn is counted as failure
+// and passed further
+func (b *Breaker) Do(fn func() error) error {
+	generation, err := b.before()
+	if err != nil {
+		return err
+	}
+	completed := false
+	defer func() {
+		if !completed {
+			b.after(generation, true, nil)
+		}
+	}()
+	err = fn()
+	completed = true
+	b.after(generation, b.IsCounted(err), err)
+	return err
+}
+
+// IsCounted returns true if error is counted as failure by breaker
+func (b *Breaker) IsCounted(err error) bool {
+	if err == nil {
+		return false
+	}
+	top, ok := cErrors.FindInStack(err, func(cErrors.CustomError) bool { return true })
+	if !ok {
+		return b.settings.CountNative
+	}
+	if !b.settings.CountOpen && errors.Is(err, ErrOpen) {
+		return false
+	}
+	return b.counts(top)
+}
+
+// before checks that call is allowed and returns generation of the current state
+func (b *Breaker) before() (uint64, error) {
+	b.mu.Lock()
+	defer b.unlock()
+	now := b.settings.Clock.Now()
+	b.refresh(now)
+
+	switch b.state {
+	case Open:
+		return 0, b.openErr(b.openedAt.Add(b.settings.OpenTimeout).Sub(now))
+	case HalfOpen:
+		if b.probes >= b.settings.MaxProbes {
+			return 0, b.openErr(0)
+		}
+		b.probes++
+	}
+	return b.generation, nil
+}
+
+// after records result of call that has been started in the generation, err is nil for panicked calls
+func (b *Breaker) after(generation uint64, counted bool, err error) {
+	b.mu.Lock()
+	defer b.unlock()
+	now := b.settings.Clock.Now()
+	b.refresh(now)
+	if generation != b.generation {
+		return
+	}
+
+	if counted {
+		if err != nil {
+			b.lastErr = err
+		}
+		switch b.state {
+		case Closed:
+			b.failures++
+			if b.failures >= b.settings.FailureThreshold {
+				b.setState(Open, now)
+			}
+		case HalfOpen:
+			b.setState(Open, now)
+		}
+		return
+	}
+
+	switch b.state {
+	case Closed:
+		b.failures = 0
+	case HalfOpen:
+		b.probes--
+		b.successes++
+		if b.successes >= b.settings.SuccessThreshold {
+			b.setState(Closed, now)
+		}
+	}
+}
+
+// refresh moves open circuit into half-open state after timeout
+func (b *Breaker) refresh(now time.Time) {
+	if b.state == Open && !now.Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		b.setState(HalfOpen, now)
+	}
+}
+
+// setState changes state and resets counters of the previous generation
+func (b *Breaker) setState(state State, now time.Time) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	b.generation++
+	b.failures = 0
+	b.successes = 0
+	b.probes = 0
+	if state == Open {
+		b.openedAt = now
+	}
+	if b.settings.OnStateChange != nil {
+		b.changes = append(b.changes, stateChange{from: from, to: state})
+	}
+}
+
+// unlock releases the lock and passes state changes into OnStateChange,
+// callback is called without lock, so it could use breaker methods
+func (b *Breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	for _, v := range changes {
+		b.settings.OnStateChange(b.settings.Name, v.from, v.to)
+	}
+}
+
+// openErr create error of rejected call, the last counted error is wrapped by it
+func (b *Breaker) openErr(retryAfter time.Duration) cErrors.CustomError {
+	opts := []cErrors.Option{
+		cErrors.WithType(cErrors.Unavailable),
+		cErrors.WithCode(ErrOpen.GetCode()),
+		cErrors.WithBaggage(cErrors.ErrorBaggage{"breaker": b.settings.Name}),
+	}
+	if retryAfter > 0 {
+		opts = append(opts, cErrors.WithRetryAfter(retryAfter))
+	}
+	if b.lastErr != nil {
+		opts = append(opts, cErrors.WithCause(b.lastErr))
+	}
+	return cErrors.Make(ErrOpen.GetMessage(), opts...)
+}
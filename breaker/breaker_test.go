@@ -0,0 +1,173 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns manually moved time
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func testBreaker(changes *[]State) (*Breaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := New(Settings{
+		Name:             "test",
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+		Clock:            clock,
+		OnStateChange: func(name string, from, to State) {
+			*changes = append(*changes, to)
+		},
+	})
+	return b, clock
+}
+
+func fail(err error) func() error {
+	return func() error {
+		return err
+	}
+}
+
+func TestBreaker_Open(t *testing.T) {
+	assertions := assert.New(t)
+	var changes []State
+	b, clock := testBreaker(&changes)
+
+	for k := 0; k < 5; k++ {
+		assertions.Error(b.Do(fail(cErrors.NotFound.NewBase("not found"))))
+		assertions.Error(b.Do(fail(cErrors.InvalidArguments.NewBase("invalid"))))
+	}
+	assertions.Equal(Closed, b.State(), "Check that client errors are not counted")
+
+	internal := cErrors.InternalError.NewBase("internal")
+	_ = b.Do(fail(internal))
+	_ = b.Do(fail(cErrors.Timeout.NewBase("timeout")))
+	_ = b.Do(fail(nil))
+	assertions.Equal(Closed, b.State(), "Check that success resets failures")
+
+	_ = b.Do(fail(internal))
+	_ = b.Do(fail(internal))
+	_ = b.Do(fail(cErrors.Unavailable.NewBase("unavailable")))
+	assertions.Equal(Open, b.State())
+	assertions.Equal([]State{Open}, changes)
+
+	called := false
+	err := b.Do(func() error {
+		called = true
+		return nil
+	})
+	assertions.False(called, "Check that open circuit rejects calls")
+	assertions.True(errors.Is(err, ErrOpen))
+	assertions.True(errors.Is(err, cErrors.Unavailable.NewBase("")))
+	assertions.Equal(cErrors.ErrorMessage("unavailable"), errors.Unwrap(err).(cErrors.CustomError).GetMessage(),
+		"Check that the last error is in chain")
+	retryAfter, ok := cErrors.RetryAfter(err)
+	assertions.True(ok)
+	assertions.Equal(time.Minute, retryAfter)
+
+	clock.Add(40 * time.Second)
+	retryAfter, _ = cErrors.RetryAfter(b.Do(fail(nil)))
+	assertions.Equal(20*time.Second, retryAfter)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	assertions := assert.New(t)
+	var changes []State
+	b, clock := testBreaker(&changes)
+	internal := cErrors.InternalError.NewBase("internal")
+
+	for k := 0; k < 3; k++ {
+		_ = b.Do(fail(internal))
+	}
+	clock.Add(time.Minute)
+	assertions.Equal(HalfOpen, b.State())
+
+	_ = b.Do(fail(internal))
+	assertions.Equal(Open, b.State(), "Check that failed probe opens circuit")
+
+	clock.Add(time.Minute)
+	err := b.Do(func() error {
+		assertions.True(errors.Is(b.Do(fail(nil)), ErrOpen), "Check that concurrent probes are limited")
+		return nil
+	})
+	assertions.NoError(err)
+	assertions.Equal(Closed, b.State(), "Check that successful probe closes circuit")
+	assertions.Equal([]State{Open, HalfOpen, Open, HalfOpen, Closed}, changes)
+
+	_ = b.Do(fail(internal))
+	_ = b.Do(fail(internal))
+	assertions.Equal(Closed, b.State(), "Check that failures are reset after closing")
+}
+
+func TestBreaker_Settings(t *testing.T) {
+	assertions := assert.New(t)
+	clock := &fakeClock{}
+	b := New(Settings{
+		Types:            []cErrors.ErrorType{cErrors.InternalError, cErrors.NotFound},
+		Severities:       []cErrors.ErrorSeverity{cErrors.Critical},
+		FailureThreshold: 1,
+		Clock:            clock,
+	})
+
+	assertions.False(b.IsCounted(cErrors.InternalError.NewBase("internal")), "Check severity condition")
+	assertions.True(b.IsCounted(cErrors.InternalError.NewBase("internal").SetSeverity(cErrors.Critical)))
+	assertions.True(b.IsCounted(cErrors.NotFound.NewBase("not found").SetSeverity(cErrors.Critical)))
+	assertions.False(b.IsCounted(cErrors.Timeout.NewBase("timeout").SetSeverity(cErrors.Critical)), "Check type condition")
+	assertions.False(b.IsCounted(errors.New("native")))
+	assertions.False(b.IsCounted(nil))
+
+	b = New(Settings{CountNative: true, FailureThreshold: 1, Clock: clock})
+	assertions.True(b.IsCounted(errors.New("native")))
+	_ = b.Do(fail(errors.New("native")))
+	assertions.Equal(Open, b.State())
+	assertions.Equal("open", b.State().String())
+}
+
+func TestBreaker_Panic(t *testing.T) {
+	assertions := assert.New(t)
+	var changes []State
+	b, _ := testBreaker(&changes)
+
+	for k := 0; k < 3; k++ {
+		assertions.PanicsWithValue("boom", func() {
+			_ = b.Do(func() error {
+				panic("boom")
+			})
+		}, "Check that panic is passed further")
+	}
+	assertions.Equal(Open, b.State(), "Check that panics are counted as failures")
+	assertions.True(errors.Is(b.Do(fail(nil)), ErrOpen))
+}
+
+func TestBreaker_CountOpen(t *testing.T) {
+	assertions := assert.New(t)
+	clock := &fakeClock{}
+	inner := New(Settings{Name: "inner", FailureThreshold: 1, Clock: clock})
+	_ = inner.Do(fail(cErrors.InternalError.NewBase("internal")))
+	openErr := inner.Do(fail(nil))
+	assertions.True(errors.Is(openErr, ErrOpen))
+
+	b := New(Settings{FailureThreshold: 1, Clock: clock})
+	assertions.False(b.IsCounted(openErr), "Check that errors of open breakers are not counted by default")
+	assertions.False(b.IsCounted(cErrors.Wrap(openErr, "call users")))
+	assertions.True(b.IsCounted(cErrors.Unavailable.NewBase("unavailable")))
+	_ = b.Do(fail(openErr))
+	assertions.Equal(Closed, b.State())
+
+	b = New(Settings{CountOpen: true, FailureThreshold: 1, Clock: clock})
+	assertions.True(b.IsCounted(openErr))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/Darevski/go-custom-errors/errstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecordJSON(t *testing.T) string {
+	err := cErrors.WrapWith(cErrors.NotFound.NewBase("no rows"), "load user",
+		cErrors.WithLevel(cErrors.UseCaseLevel), cErrors.WithSeverity(cErrors.Critical), cErrors.WithBaggage(cErrors.ErrorBaggage{"userID": 42}))
+	data, marshalErr := json.Marshal(err)
+	assert.NoError(t, marshalErr)
+	return string(data)
+}
+
+func TestExtract(t *testing.T) {
+	assertions := assert.New(t)
+	record := newRecordJSON(t)
+	escaped, _ := json.Marshal(record)
+
+	input := "2024-05-01T10:00:00Z ERROR request failed " + `{"level":"error","error":` + record + "}\n" +
+		`{"msg":"dlq message","payload":` + string(escaped) + "}\n" +
+		`{"msg":"not an error","stack":[1,2]} {broken` + "\n"
+
+	records := Extract([]byte(input))
+	if assertions.Len(records, 2) {
+		assertions.Equal("load user: no rows", records[0].Error)
+		assertions.Equal(records[0], records[1])
+		assertions.Len(records[0].Stack, 2)
+	}
+}
+
+func TestShow(t *testing.T) {
+	assertions := assert.New(t)
+	input := "INFO " + newRecordJSON(t)
+
+	var out bytes.Buffer
+	assertions.NoError(show([]string{"--format=text", "--color=never"}, strings.NewReader(input), &out))
+	text := out.String()
+	assertions.Contains(text, "Error: load user: no rows")
+	assertions.Contains(text, "#0 NotFound UseCaseLevel Critical load user")
+	assertions.Contains(text, "userID=42")
+	assertions.Contains(text, "Cause: no rows")
+	assertions.NotContains(text, "\033[")
+
+	out.Reset()
+	assertions.NoError(show([]string{"--color=always"}, strings.NewReader(input), &out))
+	assertions.Contains(out.String(), colorRed+"Critical"+colorReset, "Check severity color")
+
+	out.Reset()
+	assertions.NoError(show([]string{"--format=markdown"}, strings.NewReader(input), &out))
+	assertions.Contains(out.String(), "| 0 | NotFound | UseCaseLevel | Critical |  | load user |")
+
+	out.Reset()
+	assertions.NoError(show([]string{"--format=json"}, strings.NewReader(input), &out))
+	var record cErrors.ErrorRecord
+	assertions.NoError(json.Unmarshal(out.Bytes(), &record))
+	assertions.Equal("load user: no rows", record.Error)
+
+	assertions.Error(show(nil, strings.NewReader("no errors here"), &out))
+	assertions.Error(show([]string{"--format=yaml"}, strings.NewReader(input), &out))
+}
+
+func TestQuery(t *testing.T) {
+	assertions := assert.New(t)
+	dir := t.TempDir()
+	current := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		current = current.Add(time.Minute)
+		return current
+	}
+	store, err := errstore.Open(errstore.Options{Dir: dir, Now: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := 0; k < 3; k++ {
+		assertions.NoError(store.Record(cErrors.NotFound.NewBase("no rows").SetBaggage(cErrors.ErrorBaggage{"userID": k})))
+	}
+	assertions.NoError(store.Record(cErrors.InternalError.NewBase("timeout").SetSeverity(cErrors.Critical)))
+	assertions.NoError(store.Close())
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	var out bytes.Buffer
+	assertions.NoError(query([]string{"--dir", dir, "--type=NotFound", "--baggage=userID=1"}, nil, &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if assertions.Len(lines, 1) {
+		assertions.Contains(lines[0], "2020-01-01T12:02:00Z")
+		assertions.Contains(lines[0], "NotFound")
+		assertions.Contains(lines[0], "no rows")
+	}
+
+	out.Reset()
+	assertions.NoError(query([]string{"--dir", dir, "--since=90s", "--format=json"}, nil, &out))
+	assertions.Equal(2, strings.Count(out.String(), "\n"), "Check time range")
+
+	out.Reset()
+	assertions.NoError(query([]string{"--dir", dir, "--group-by=type", "--top=1"}, nil, &out))
+	lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+	if assertions.Len(lines, 2) {
+		assertions.Regexp(`^3\s+NotFound\s+2020-01-01T12:03:00Z\s+no rows$`, lines[1])
+	}
+
+	out.Reset()
+	assertions.NoError(query([]string{"--dir", dir, "--group-by=severity", "--format=json"}, nil, &out))
+	var groups []errstore.Group
+	assertions.NoError(json.Unmarshal(out.Bytes(), &groups))
+	assertions.Len(groups, 2)
+
+	out.Reset()
+	assertions.NoError(query([]string{"--dir", dir, "--type=UserNotFound"}, nil, &out), "Check that catalog types could be queried")
+	assertions.Empty(out.String())
+	assertions.Error(query([]string{"--dir", dir, "--group-by=unknown"}, nil, &out))
+}
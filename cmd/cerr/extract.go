@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Extract returns all error records found in data. Data is scanned for JSON values, records are searched
+// in nested objects, arrays and strings with escaped JSON, e.g. in {"msg": "failed", "error": {"stack": [...]}}
+func Extract(data []byte) []cErrors.ErrorRecord {
+	var records []cErrors.ErrorRecord
+	for k := 0; k < len(data); k++ {
+		if data[k] != '{' {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data[k:]))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			continue
+		}
+		records = append(records, find(value)...)
+		k += int(decoder.InputOffset()) - 1
+	}
+	return records
+}
+
+// find returns records of decoded JSON value
+func find(value interface{}) []cErrors.ErrorRecord {
+	var records []cErrors.ErrorRecord
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if record, ok := asRecord(v); ok {
+			return []cErrors.ErrorRecord{record}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			records = append(records, find(v[key])...)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			records = append(records, find(nested)...)
+		}
+	case string:
+		if s := strings.TrimSpace(v); strings.HasPrefix(s, "{") {
+			records = append(records, Extract([]byte(s))...)
+		}
+	}
+	return records
+}
+
+// asRecord converts object into record if it has not empty stack of layers with types
+func asRecord(object map[string]interface{}) (cErrors.ErrorRecord, bool) {
+	var record cErrors.ErrorRecord
+	stack, ok := object["stack"].([]interface{})
+	if !ok || len(stack) == 0 {
+		return record, false
+	}
+	for _, layer := range stack {
+		fields, ok := layer.(map[string]interface{})
+		if !ok {
+			return record, false
+		}
+		if _, ok = fields["type"]; !ok {
+			return record, false
+		}
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return record, false
+	}
+	return record, json.Unmarshal(data, &record) == nil
+}
@@ -0,0 +1,56 @@
+// Command cerr works with serialized custom errors (see errors.ErrorRecord).
+//
+//	cerr show [--format=text|json|markdown] [--color=auto|always|never] [file...]
+//
+//	cerr query [--dir=dir] [--type=T]... [--level=L]... [--severity=S]... [--baggage=key=value]...
+//	           [--fingerprint=F] [--since=1h] [--until=T] [--limit=N] [--group-by=fingerprint] [--top=N] [--format=text|json]
+//
+// show reads errors from files or stdin and prints them. Errors could be embedded into larger
+// structured log lines or dead-letter queue messages, every error JSON found in input is printed.
+//
+// query reads errors from the store written by errstore package, it prints matched errors or
+// summary of them grouped by key, e.g. top fingerprints of the last hour
+//
+//	cerr query --dir=/var/lib/users/errors --since=1h --group-by=fingerprint --top=5
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a subcommand of cerr
+type command struct {
+	run   func(args []string, stdin io.Reader, stdout io.Writer) error
+	usage string
+}
+
+var commands = map[string]command{
+	"show": {run: show, usage: "show [--format=text|json|markdown] [--color=auto|always|never] [file...]"},
+	"query": {run: query, usage: "query [--dir=dir] [--type=T]... [--level=L]... [--severity=S]... [--baggage=key=value]... " +
+		"[--fingerprint=F] [--since=T] [--until=T] [--limit=N] [--group-by=key] [--top=N] [--format=text|json]"},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "cerr:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, name := range []string{"show", "query"} {
+		fmt.Fprintln(os.Stderr, "  cerr", commands[name].usage)
+	}
+}
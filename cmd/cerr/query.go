@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Darevski/go-custom-errors/errstore"
+)
+
+// now returns current time, could be replaced in tests
+var now = time.Now
+
+// repeated is a flag that could be specified several times
+type repeated []string
+
+func (r *repeated) String() string {
+	return strings.Join(*r, ",")
+}
+
+func (r *repeated) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+func query(args []string, _ io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "directory of error store")
+	var types, levels, severities, baggage repeated
+	flags.Var(&types, "type", "error type, could be repeated")
+	flags.Var(&levels, "level", "error level, could be repeated")
+	flags.Var(&severities, "severity", "error severity, could be repeated")
+	flags.Var(&baggage, "baggage", "baggage key=value, could be repeated")
+	fingerprint := flags.String("fingerprint", "", "error fingerprint")
+	since := flags.String("since", "", "RFC3339 time or duration before now, e.g. 1h")
+	until := flags.String("until", "", "RFC3339 time or duration before now")
+	limit := flags.Int("limit", 0, "max count of errors, 0 means unlimited")
+	groupBy := flags.String("group-by", "", "summarize errors by fingerprint, type, level, severity, code or baggage:<key>")
+	top := flags.Int("top", 10, "max count of groups, 0 means unlimited")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	values := url.Values{"type": types, "level": levels, "severity": severities, "baggage": baggage,
+		"fingerprint": {*fingerprint}, "since": {*since}, "until": {*until}}
+	if *groupBy == "" && *limit > 0 {
+		values.Set("limit", fmt.Sprint(*limit))
+	}
+	filter, err := errstore.ParseFilter(values, now())
+	if err != nil {
+		return err
+	}
+	entries, err := errstore.Query(*dir, filter)
+	if err != nil {
+		return err
+	}
+
+	if *groupBy == "" {
+		return writeEntries(stdout, entries, *format)
+	}
+	groups, err := errstore.GroupBy(entries, *groupBy)
+	if err != nil {
+		return err
+	}
+	if *top > 0 && len(groups) > *top {
+		groups = groups[:*top]
+	}
+	return writeGroups(stdout, groups, *format)
+}
+
+func writeEntries(w io.Writer, entries []errstore.Entry, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, entry := range entries {
+			top := entry.Stack[0]
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Time.Format(time.RFC3339), entry.Fingerprint,
+				top.Type, top.Level, top.Severity, entry.Error)
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func writeGroups(w io.Writer, groups []errstore.Group, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if groups == nil {
+			groups = []errstore.Group{}
+		}
+		return encoder.Encode(groups)
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "COUNT\tKEY\tLAST\tEXAMPLE")
+		for _, group := range groups {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", group.Count, group.Key, group.Last.Format(time.RFC3339), group.Example)
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// ANSI colors of text output
+const (
+	colorReset  = "\033[0m"
+	colorBold   = "\033[1m"
+	colorDim    = "\033[2m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+)
+
+func show(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("show", flag.ContinueOnError)
+	format := flags.String("format", "text", "output format: text, json or markdown")
+	color := flags.String("color", "auto", "colorize text output: auto, always or never")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return err
+	}
+	records := Extract(data)
+	if len(records) == 0 {
+		return fmt.Errorf("no errors found in input")
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		for _, record := range records {
+			if err = encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+	case "text":
+		p := printer{w: stdout, color: useColor(*color, stdout)}
+		for k, record := range records {
+			if k > 0 {
+				fmt.Fprintln(stdout)
+			}
+			p.text(record)
+		}
+	case "markdown":
+		for k, record := range records {
+			if k > 0 {
+				fmt.Fprintln(stdout)
+			}
+			markdown(stdout, record)
+		}
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	return nil
+}
+
+// readInput reads and joins files, stdin is read if there are no files
+func readInput(files []string, stdin io.Reader) ([]byte, error) {
+	if len(files) == 0 {
+		return io.ReadAll(stdin)
+	}
+	var data []byte
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		data = append(append(data, content...), '\n')
+	}
+	return data, nil
+}
+
+// useColor returns true if text output should be colorized, auto mode colorizes output to terminal
+func useColor(mode string, w io.Writer) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	file, ok := w.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printer writes records in text format
+type printer struct {
+	w     io.Writer
+	color bool
+}
+
+// paint wraps text into color codes
+func (p printer) paint(color, text string) string {
+	if !p.color || text == "" {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// severityColor returns color of layer by severity
+func severityColor(severity string) string {
+	switch severity {
+	case cErrors.Critical.String(), cErrors.Fatal.String(), cErrors.Panic.String():
+		return colorRed
+	case cErrors.Warning.String():
+		return colorYellow
+	}
+	return colorCyan
+}
+
+func (p printer) text(record cErrors.ErrorRecord) {
+	fmt.Fprintf(p.w, "%s %s\n", p.paint(colorBold, "Error:"), record.Error)
+	if record.Fingerprint != "" {
+		fmt.Fprintf(p.w, "%s %s\n", p.paint(colorBold, "Fingerprint:"), record.Fingerprint)
+	}
+	fmt.Fprintln(p.w, p.paint(colorBold, "Stack:"))
+	for k, layer := range record.Stack {
+		color := severityColor(layer.Severity)
+		fmt.Fprintf(p.w, "  #%d %s %s %s %s\n", k, p.paint(color, layer.Type), layer.Level, p.paint(color, layer.Severity), layer.Message)
+		if layer.Code != "" {
+			fmt.Fprintf(p.w, "     code: %s\n", layer.Code)
+		}
+		if layer.PublicMessage != "" || layer.PublicCode != "" {
+			fmt.Fprintf(p.w, "     public: %s %s\n", layer.PublicMessage, layer.PublicCode)
+		}
+		if layer.Path != "" {
+			fmt.Fprintf(p.w, "     %s\n", p.paint(colorDim, "at "+layer.Path))
+		}
+		for _, line := range baggageLines(layer.Baggage) {
+			fmt.Fprintf(p.w, "     %s\n", line)
+		}
+	}
+	if record.Cause != "" {
+		fmt.Fprintf(p.w, "%s %s\n", p.paint(colorBold, "Cause:"), p.paint(colorRed, record.Cause))
+	}
+	if len(record.Violations) > 0 {
+		fmt.Fprintln(p.w, p.paint(colorBold, "Violations:"))
+		for _, v := range record.Violations {
+			fmt.Fprintf(p.w, "  %s (%s): %s\n", v.Field, v.Rule, v.Message)
+		}
+	}
+}
+
+func markdown(w io.Writer, record cErrors.ErrorRecord) {
+	fmt.Fprintf(w, "### %s\n\n", escapeMarkdown(record.Error))
+	if record.Fingerprint != "" {
+		fmt.Fprintf(w, "Fingerprint: `%s`\n\n", record.Fingerprint)
+	}
+	fmt.Fprintln(w, "| # | Type | Level | Severity | Code | Message | Path | Baggage |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- | --- | --- | --- |")
+	for k, layer := range record.Stack {
+		fmt.Fprintf(w, "| %d | %s | %s | %s | %s | %s | %s | %s |\n", k, layer.Type, layer.Level, layer.Severity,
+			layer.Code, escapeMarkdown(layer.Message), escapeMarkdown(layer.Path), escapeMarkdown(strings.Join(baggageLines(layer.Baggage), ", ")))
+	}
+	if record.Cause != "" {
+		fmt.Fprintf(w, "\nCause:\n\n```\n%s\n```\n", record.Cause)
+	}
+	if len(record.Violations) > 0 {
+		fmt.Fprintln(w, "\nViolations:")
+		fmt.Fprintln(w)
+		for _, v := range record.Violations {
+			fmt.Fprintf(w, "- `%s` (%s): %s\n", v.Field, v.Rule, escapeMarkdown(v.Message))
+		}
+	}
+}
+
+// baggageLines returns baggage as sorted key=value lines
+func baggageLines(baggage cErrors.ErrorBaggage) []string {
+	lines := make([]string, 0, len(baggage))
+	for key, value := range baggage {
+		if data, err := json.Marshal(value); err == nil {
+			lines = append(lines, key+"="+strings.Trim(string(data), `"`))
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
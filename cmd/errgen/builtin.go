@@ -0,0 +1,66 @@
+package main
+
+// errgen does not import errors package, so const.go of errors package could be generated even if it is broken.
+// Built-in values are duplicated here, TestBuiltin checks that they match errors package
+
+// typeInfo describes standard attributes of error type, see errors.TypeInfo
+type typeInfo struct {
+	HTTPStatus int
+	GRPCCode   uint32
+	Retryable  bool
+	Severity   string
+}
+
+// builtinType is a built-in error type, value of type is its index in builtinTypes
+type builtinType struct {
+	Name   string
+	Parent string
+	Info   typeInfo
+}
+
+// unknownTypeInfo is used for types without info and without parents with info
+var unknownTypeInfo = typeInfo{HTTPStatus: 500, GRPCCode: 2, Severity: "Critical"}
+
+var builtinTypes = []builtinType{
+	{Name: "DefaultType", Info: unknownTypeInfo},
+	{Name: "NotFound", Parent: "ClientError", Info: typeInfo{HTTPStatus: 404, GRPCCode: 5, Severity: "Info"}},
+	{Name: "InvalidArguments", Parent: "ClientError", Info: typeInfo{HTTPStatus: 400, GRPCCode: 3, Severity: "Info"}},
+	{Name: "InternalError", Parent: "ServerError", Info: typeInfo{HTTPStatus: 500, GRPCCode: 13, Severity: "Critical"}},
+	{Name: "BadRequest", Parent: "ClientError", Info: typeInfo{HTTPStatus: 400, GRPCCode: 3, Severity: "Info"}},
+	{Name: "AccessDenied", Parent: "ClientError", Info: typeInfo{HTTPStatus: 403, GRPCCode: 7, Severity: "Warning"}},
+	{Name: "Unauthorized", Parent: "ClientError", Info: typeInfo{HTTPStatus: 401, GRPCCode: 16, Severity: "Warning"}},
+	{Name: "ClientError", Info: typeInfo{HTTPStatus: 400, GRPCCode: 3, Severity: "Info"}},
+	{Name: "ServerError", Info: typeInfo{HTTPStatus: 500, GRPCCode: 13, Severity: "Critical"}},
+	{Name: "Conflict", Parent: "ClientError", Info: typeInfo{HTTPStatus: 409, GRPCCode: 10, Severity: "Warning"}},
+	{Name: "AlreadyExists", Parent: "Conflict", Info: typeInfo{HTTPStatus: 409, GRPCCode: 6, Severity: "Info"}},
+	{Name: "PreconditionFailed", Parent: "ClientError", Info: typeInfo{HTTPStatus: 412, GRPCCode: 9, Severity: "Info"}},
+	{Name: "RateLimited", Parent: "ClientError", Info: typeInfo{HTTPStatus: 429, GRPCCode: 8, Retryable: true, Severity: "Warning"}},
+	{Name: "Canceled", Parent: "ClientError", Info: typeInfo{HTTPStatus: 499, GRPCCode: 1, Severity: "Info"}},
+	{Name: "Timeout", Parent: "ServerError", Info: typeInfo{HTTPStatus: 504, GRPCCode: 4, Retryable: true, Severity: "Warning"}},
+	{Name: "Unavailable", Parent: "ServerError", Info: typeInfo{HTTPStatus: 503, GRPCCode: 14, Retryable: true, Severity: "Warning"}},
+	{Name: "NotImplemented", Parent: "ServerError", Info: typeInfo{HTTPStatus: 501, GRPCCode: 12, Severity: "Warning"}},
+	{Name: "DataLoss", Parent: "ServerError", Info: typeInfo{HTTPStatus: 500, GRPCCode: 15, Severity: "Critical"}},
+}
+
+var builtinLevels = []string{"DefaultLevel", "DataLevel", "UseCaseLevel", "ContainerLevel", "ControllerLevel", "TransportLevel"}
+
+var builtinSeverities = []string{"DefaultSeverity", "Debug", "Info", "Warning", "Critical", "Fatal", "Panic"}
+
+// builtinInfo returns info of built-in type by value or name, unknownTypeInfo is returned for other types
+func builtinInfo(value int, name string) typeInfo {
+	for k, t := range builtinTypes {
+		if (name == "" && k == value) || (name != "" && t.Name == name) {
+			return t.Info
+		}
+	}
+	return unknownTypeInfo
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
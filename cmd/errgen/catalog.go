@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog describes enums, error types and domain errors of a package
+type Catalog struct {
+	// Package is a name of generated package, $GOPACKAGE is used if empty
+	Package string  `json:"package" yaml:"package"`
+	Enums   []Enum  `json:"enums" yaml:"enums"`
+	Types   []Type  `json:"types" yaml:"types"`
+	Errors  []Error `json:"errors" yaml:"errors"`
+}
+
+// Enum is an integer type with named values, values are numbered from zero in order of declaration.
+// String method and table of names are generated for it
+type Enum struct {
+	Type   string   `json:"type" yaml:"type"`
+	Values []string `json:"values" yaml:"values"`
+	// Fallback is a function func(Type) (string, bool) that names values without names
+	Fallback string `json:"fallback" yaml:"fallback"`
+}
+
+// Type is an own error type
+type Type struct {
+	Name  string `json:"name" yaml:"name"`
+	Value uint   `json:"value" yaml:"value"`
+	Doc   string `json:"doc" yaml:"doc"`
+	// Parent is a name of parent category, e.g. ClientError
+	Parent string `json:"parent" yaml:"parent"`
+	// Status, GRPC, Retryable and Severity override info of parent type, info is registered with SetTypeInfo
+	// when any of them is set
+	Status    int    `json:"status" yaml:"status"`
+	GRPC      uint32 `json:"grpc" yaml:"grpc"`
+	Retryable *bool  `json:"retryable" yaml:"retryable"`
+	Severity  string `json:"severity" yaml:"severity"`
+	// Public is a generic public message of type
+	Public string `json:"public" yaml:"public"`
+}
+
+// Error is a domain error, sentinel and typed constructor are generated for it
+type Error struct {
+	Name string `json:"name" yaml:"name"`
+	Code string `json:"code" yaml:"code"`
+	Doc  string `json:"doc" yaml:"doc"`
+	Type string `json:"type" yaml:"type"`
+	// Level and Severity are default ones if empty
+	Level    string `json:"level" yaml:"level"`
+	Severity string `json:"severity" yaml:"severity"`
+	// Message is an internal message template, parameters in braces are replaced with values, e.g. "user {userID} not found"
+	Message string `json:"message" yaml:"message"`
+	Public  string `json:"public" yaml:"public"`
+	// Status is HTTP status of error, it must be equal to status of error type
+	Status int     `json:"status" yaml:"status"`
+	Params []Param `json:"params" yaml:"params"`
+}
+
+// Param is a parameter of error constructor, it is stored in error baggage
+type Param struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is a Go type of parameter, e.g. int64 or time.Duration
+	Type string `json:"type" yaml:"type"`
+}
+
+// placeholder is a parameter of message template
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// LoadCatalog reads catalog from .json, .yaml or .yml file, unknown fields are not allowed
+func LoadCatalog(path string) (Catalog, error) {
+	var catalog Catalog
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return catalog, err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&catalog)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&catalog)
+	default:
+		err = fmt.Errorf("unsupported catalog format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return catalog, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return catalog, catalog.Validate()
+}
+
+// Validate checks names, references to types, levels and severities, parameters of message templates and HTTP statuses
+func (c Catalog) Validate() error {
+	names := make(map[string]bool)
+	declare := func(name string) error {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("%q is not a valid identifier", name)
+		}
+		if names[name] {
+			return fmt.Errorf("%q is declared twice", name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	for _, enum := range c.Enums {
+		if !token.IsIdentifier(enum.Type) {
+			return fmt.Errorf("enum type %q is not a valid identifier", enum.Type)
+		}
+		for _, value := range enum.Values {
+			if err := declare(value); err != nil {
+				return fmt.Errorf("enum %s: %w", enum.Type, err)
+			}
+		}
+	}
+
+	types := make(map[string]Type)
+	values := make(map[uint]string)
+	for _, t := range c.Types {
+		if err := declare(t.Name); err != nil {
+			return fmt.Errorf("type: %w", err)
+		}
+		if t.Value < uint(len(builtinTypes)) {
+			return fmt.Errorf("type %s: value %d is reserved for built-in types, use values from %d",
+				t.Name, t.Value, len(builtinTypes))
+		}
+		if previous, ok := values[t.Value]; ok {
+			return fmt.Errorf("type %s: value %d is already used by type %s", t.Name, t.Value, previous)
+		}
+		values[t.Value] = t.Name
+		types[t.Name] = t
+	}
+	for _, t := range c.Types {
+		if t.Parent != "" && !c.hasType(t.Parent) {
+			return fmt.Errorf("type %s: unknown parent type %q", t.Name, t.Parent)
+		}
+		for parent, depth := t.Parent, 0; parent != ""; parent, depth = types[parent].Parent, depth+1 {
+			if parent == t.Name || depth > len(types) {
+				return fmt.Errorf("type %s: parent types form a cycle", t.Name)
+			}
+		}
+		if t.Severity != "" && !isSeverityName(t.Severity) {
+			return fmt.Errorf("type %s: unknown severity %q", t.Name, t.Severity)
+		}
+	}
+
+	params := make(map[string]string)
+	codes := make(map[string]bool)
+	for _, e := range c.Errors {
+		if err := declare(e.Name); err != nil {
+			return fmt.Errorf("error: %w", err)
+		}
+		if e.Code == "" || codes[e.Code] {
+			return fmt.Errorf("error %s: code %q is empty or not unique", e.Name, e.Code)
+		}
+		codes[e.Code] = true
+		if !c.hasType(e.Type) {
+			return fmt.Errorf("error %s: unknown type %q", e.Name, e.Type)
+		}
+		if e.Level != "" && !isLevelName(e.Level) {
+			return fmt.Errorf("error %s: unknown level %q", e.Name, e.Level)
+		}
+		if e.Severity != "" && !isSeverityName(e.Severity) {
+			return fmt.Errorf("error %s: unknown severity %q", e.Name, e.Severity)
+		}
+		if status := c.status(e.Type); e.Status != 0 && e.Status != status {
+			return fmt.Errorf("error %s: status %d differs from status %d of type %s, declare own type with this status",
+				e.Name, e.Status, status, e.Type)
+		}
+
+		declared := make(map[string]bool)
+		for _, p := range e.Params {
+			if !token.IsIdentifier(p.Name) || p.Type == "" {
+				return fmt.Errorf("error %s: invalid parameter %q of type %q", e.Name, p.Name, p.Type)
+			}
+			if previous, ok := params[p.Name]; ok && previous != p.Type {
+				return fmt.Errorf("error %s: parameter %s has type %s, but it is %s in other errors", e.Name, p.Name, p.Type, previous)
+			}
+			params[p.Name] = p.Type
+			declared[p.Name] = true
+		}
+		for _, match := range placeholder.FindAllStringSubmatch(e.Message, -1) {
+			if !declared[match[1]] {
+				return fmt.Errorf("error %s: message uses undeclared parameter %q", e.Name, match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// hasType returns true if type is declared in catalog or it is a built-in one
+func (c Catalog) hasType(name string) bool {
+	for _, t := range c.Types {
+		if t.Name == name {
+			return true
+		}
+	}
+	return isTypeName(name)
+}
+
+// depth returns count of ancestors of type that are declared in catalog
+func (c Catalog) depth(name string) int {
+	for _, t := range c.Types {
+		if t.Name == name && t.Parent != "" {
+			return c.depth(t.Parent) + 1
+		}
+	}
+	return 0
+}
+
+// status returns HTTP status of type, see info
+func (c Catalog) status(name string) int {
+	return c.info(name).HTTPStatus
+}
+
+// info returns attributes of type declared in catalog, attributes of the closest parent are used if type has not own ones.
+// Attributes of built-in types are used for other types
+func (c Catalog) info(name string) typeInfo {
+	for _, t := range c.Types {
+		if t.Name != name {
+			continue
+		}
+		info := builtinInfo(int(t.Value), "")
+		if t.Parent != "" {
+			info = c.info(t.Parent)
+		}
+		if t.Status != 0 {
+			info.HTTPStatus = t.Status
+		}
+		if t.GRPC != 0 {
+			info.GRPCCode = t.GRPC
+		}
+		if t.Retryable != nil {
+			info.Retryable = *t.Retryable
+		}
+		if t.Severity != "" {
+			info.Severity = t.Severity
+		}
+		return info
+	}
+	return builtinInfo(0, name)
+}
+
+// isTypeName, isLevelName and isSeverityName return true if s is an exact name of built-in value
+func isTypeName(s string) bool {
+	for _, t := range builtinTypes {
+		if t.Name == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isLevelName(s string) bool {
+	return contains(builtinLevels, s)
+}
+
+func isSeverityName(s string) bool {
+	return contains(builtinSeverities, s)
+}
+
+// exported returns identifier with upper case first letter
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// unexported returns identifier with lower case first letter
+func unexported(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateExample(t *testing.T) {
+	assertions := assert.New(t)
+	catalog, err := LoadCatalog("example/users/errors.yaml")
+	if !assertions.NoError(err) {
+		return
+	}
+
+	code, err := Generate(catalog, "errors.yaml", false)
+	assertions.NoError(err)
+	assertGolden(t, "example/users/errors_gen.go", code)
+	assertGolden(t, "example/users/errors.md", Markdown(catalog, "errors.yaml"))
+}
+
+func TestGenerateRoot(t *testing.T) {
+	assertions := assert.New(t)
+	catalog, err := LoadCatalog("../../errgen.yaml")
+	if !assertions.NoError(err) {
+		return
+	}
+
+	self, err := isRootPackage("../..")
+	assertions.NoError(err)
+	assertions.True(self)
+	code, err := Generate(catalog, "errgen.yaml", self)
+	assertions.NoError(err)
+	assertGolden(t, "../../const.go", code)
+}
+
+func TestBuiltin(t *testing.T) {
+	assertions := assert.New(t)
+	for k, v := range builtinTypes {
+		errType := cErrors.ErrorType(k)
+		assertions.Equal(errType.String(), v.Name)
+		parent, _ := errType.Parent()
+		if v.Parent != "" {
+			assertions.Equal(parent.String(), v.Parent, v.Name)
+		}
+		info := errType.Info()
+		assertions.Equal(typeInfo{HTTPStatus: info.HTTPStatus, GRPCCode: uint32(info.GRPCCode), Retryable: info.Retryable,
+			Severity: info.Severity.String()}, v.Info, v.Name)
+	}
+	assertions.Equal(fmt.Sprintf("ErrorType(%d)", len(builtinTypes)), cErrors.ErrorType(len(builtinTypes)).String(),
+		"Check that all built-in types are described")
+	for k, v := range builtinLevels {
+		assertions.Equal(cErrors.ErrorLevel(k).String(), v)
+	}
+	for k, v := range builtinSeverities {
+		assertions.Equal(cErrors.ErrorSeverity(k).String(), v)
+	}
+}
+
+func TestLoadCatalogJSON(t *testing.T) {
+	assertions := assert.New(t)
+	path := filepath.Join(t.TempDir(), "errors.json")
+	assertions.NoError(os.WriteFile(path, []byte(`{
+		"package": "orders",
+		"errors": [{"name": "OrderNotFound", "code": "order.not_found", "type": "NotFound", "message": "order {id} not found",
+			"params": [{"name": "id", "type": "string"}]}]
+	}`), 0o644))
+
+	catalog, err := LoadCatalog(path)
+	assertions.NoError(err)
+	assertions.Equal("orders", catalog.Package)
+	assertions.Len(catalog.Errors, 1)
+
+	assertions.NoError(os.WriteFile(path, []byte(`{"package": "orders", "unknown": true}`), 0o644))
+	_, err = LoadCatalog(path)
+	assertions.Error(err, "Check unknown fields")
+}
+
+func TestValidate(t *testing.T) {
+	valid := Error{Name: "UserNotFound", Code: "user.not_found", Type: "NotFound", Message: "user not found"}
+	cases := map[string]Catalog{
+		"duplicate name":     {Errors: []Error{valid, valid}},
+		"unknown type":       {Errors: []Error{{Name: "A", Code: "a", Type: "Missing"}}},
+		"unknown level":      {Errors: []Error{{Name: "A", Code: "a", Type: "NotFound", Level: "Upper"}}},
+		"status mismatch":    {Errors: []Error{{Name: "A", Code: "a", Type: "NotFound", Status: 410}}},
+		"undeclared param":   {Errors: []Error{{Name: "A", Code: "a", Type: "NotFound", Message: "user {id}"}}},
+		"conflicting params": {Errors: []Error{{Name: "A", Code: "a", Type: "NotFound", Params: []Param{{"id", "int"}}}, {Name: "B", Code: "b", Type: "NotFound", Params: []Param{{"id", "string"}}}}},
+		"unknown parent":     {Types: []Type{{Name: "Teapot", Value: 100, Parent: "Missing"}}},
+		"invalid identifier": {Enums: []Enum{{Type: "Color", Values: []string{"not-valid"}}}},
+		"built-in value":     {Types: []Type{{Name: "Teapot", Value: 17}}},
+		"duplicate value":    {Types: []Type{{Name: "Teapot", Value: 100}, {Name: "Gone", Value: 100}}},
+		"parent cycle":       {Types: []Type{{Name: "Teapot", Value: 100, Parent: "Gone"}, {Name: "Gone", Value: 101, Parent: "Teapot"}}},
+	}
+	for name, catalog := range cases {
+		assert.Error(t, catalog.Validate(), name)
+	}
+
+	assert.NoError(t, Catalog{
+		Types:  []Type{{Name: "Gone", Value: 100, Parent: "NotFound", Status: 410}},
+		Errors: []Error{valid, {Name: "A", Code: "a", Type: "Gone", Status: 410}},
+	}.Validate())
+}
+
+func TestGenerate_TypeInfoOrder(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := Catalog{Package: "shop", Types: []Type{
+		{Name: "OrderGone", Value: 101, Parent: "OrderError", Status: 410},
+		{Name: "OrderError", Value: 100, Parent: "ClientError", Status: 422, Severity: "Warning"},
+	}}
+	assertions.NoError(catalog.Validate())
+
+	code, err := Generate(catalog, "errors.yaml", false)
+	if !assertions.NoError(err) {
+		return
+	}
+	parent := strings.Index(string(code), "cErrors.SetTypeInfo(OrderError, info)")
+	child := strings.Index(string(code), "info := OrderGone.Info()")
+	assertions.True(parent >= 0 && child > parent, "Check that child inherits info of parent declared after it")
+}
+
+// assertGolden compares generated content with checked in file
+func assertGolden(t *testing.T, path string, actual []byte) {
+	expected, err := os.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(expected), string(actual), "%s is outdated, run go generate", path)
+	}
+}
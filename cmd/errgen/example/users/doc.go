@@ -0,0 +1,4 @@
+// Package users is an example of errors generated by errgen from errors.yaml catalog
+package users
+
+//go:generate go run github.com/Darevski/go-custom-errors/cmd/errgen -catalog errors.yaml -output errors_gen.go -doc errors.md
@@ -0,0 +1,108 @@
+// Code generated by errgen from errors.yaml; DO NOT EDIT.
+
+package users
+
+import (
+	"fmt"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Error types
+const (
+	// PaymentRequired is returned when subscription is expired
+	PaymentRequired = cErrors.ErrorType(100)
+)
+
+func init() {
+	cErrors.SetTypeName(PaymentRequired, "PaymentRequired")
+	cErrors.SetTypeParent(PaymentRequired, cErrors.ClientError)
+}
+
+// Baggage keys of error parameters
+const (
+	BaggageUserID     = "userID"
+	BaggageExpiredFor = "expiredFor"
+)
+
+// UserIDFrom returns userID parameter of error, the outermost value in the error chain is used
+func UserIDFrom(err error) (int64, bool) {
+	var value int64
+	layer, ok := cErrors.FindInStack(err, cErrors.HasBaggageKey(BaggageUserID))
+	if ok {
+		value, ok = layer.GetBaggage()[BaggageUserID].(int64)
+	}
+	return value, ok
+}
+
+// ExpiredForFrom returns expiredFor parameter of error, the outermost value in the error chain is used
+func ExpiredForFrom(err error) (time.Duration, bool) {
+	var value time.Duration
+	layer, ok := cErrors.FindInStack(err, cErrors.HasBaggageKey(BaggageExpiredFor))
+	if ok {
+		value, ok = layer.GetBaggage()[BaggageExpiredFor].(time.Duration)
+	}
+	return value, ok
+}
+
+// Options of errors
+var (
+	userNotFoundOptions = cErrors.Options(
+		cErrors.WithType(cErrors.NotFound),
+		cErrors.WithCode("user.not_found"),
+		cErrors.WithLevel(cErrors.DataLevel),
+		cErrors.WithSeverity(cErrors.Info),
+		cErrors.WithPublicMessage("User not found"),
+	)
+	subscriptionExpiredOptions = cErrors.Options(
+		cErrors.WithType(PaymentRequired),
+		cErrors.WithCode("user.subscription_expired"),
+		cErrors.WithLevel(cErrors.UseCaseLevel),
+		cErrors.WithPublicMessage("Subscription expired"),
+	)
+	emailTakenOptions = cErrors.Options(
+		cErrors.WithType(cErrors.AlreadyExists),
+		cErrors.WithCode("user.email_taken"),
+		cErrors.WithPublicMessage("Email is already taken"),
+	)
+)
+
+// Sentinels of errors, they are compared with errors of the same code by errors.Is
+var (
+	// UserNotFound is a sentinel of user.not_found errors
+	UserNotFound = cErrors.NewSentinel("user.not_found", cErrors.NotFound, "user {userID} not found", userNotFoundOptions)
+	// SubscriptionExpired is a sentinel of user.subscription_expired errors
+	SubscriptionExpired = cErrors.NewSentinel("user.subscription_expired", PaymentRequired, "subscription of user {userID} expired {expiredFor} ago", subscriptionExpiredOptions)
+	// EmailTaken is a sentinel of user.email_taken errors
+	EmailTaken = cErrors.NewSentinel("user.email_taken", cErrors.AlreadyExists, "email is already taken", emailTakenOptions)
+)
+
+// ErrUserNotFound create user.not_found error
+func ErrUserNotFound(userID int64) cErrors.CustomError {
+	return cErrors.Make(cErrors.ErrorMessage(fmt.Sprintf("user %v not found", userID)), userNotFoundOptions, cErrors.WithCallerSkip(1), cErrors.WithBaggage(cErrors.ErrorBaggage{
+		BaggageUserID: userID,
+	}))
+}
+
+// ErrSubscriptionExpired create user.subscription_expired error
+func ErrSubscriptionExpired(userID int64, expiredFor time.Duration) cErrors.CustomError {
+	return cErrors.Make(cErrors.ErrorMessage(fmt.Sprintf("subscription of user %v expired %v ago", userID, expiredFor)), subscriptionExpiredOptions, cErrors.WithCallerSkip(1), cErrors.WithBaggage(cErrors.ErrorBaggage{
+		BaggageUserID:     userID,
+		BaggageExpiredFor: expiredFor,
+	}))
+}
+
+// ErrEmailTaken create user.email_taken error
+func ErrEmailTaken() cErrors.CustomError {
+	return cErrors.Make("email is already taken", emailTakenOptions, cErrors.WithCallerSkip(1))
+}
+
+func init() {
+	info := PaymentRequired.Info()
+	info.HTTPStatus = 402
+	info.GRPCCode = cErrors.GRPCCode(9)
+	info.Severity = cErrors.Warning
+	cErrors.SetTypeInfo(PaymentRequired, info)
+	cErrors.SetTypePublicMessage(PaymentRequired, "Payment required")
+}
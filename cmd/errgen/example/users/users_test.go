@@ -0,0 +1,54 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedErrors(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := cErrors.Wrap(ErrUserNotFound(42), "load profile")
+	assertions.True(errors.Is(err, UserNotFound))
+	assertions.False(errors.Is(err, EmailTaken))
+	assertions.Equal("load profile: user 42 not found", err.Error())
+	assertions.Equal(cErrors.ErrorCode("user.not_found"), err.GetCode())
+	assertions.Equal(cErrors.ErrorMessage("User not found"), cErrors.PublicMessage(err))
+
+	userID, ok := UserIDFrom(err)
+	assertions.True(ok)
+	assertions.Equal(int64(42), userID)
+	_, ok = ExpiredForFrom(err)
+	assertions.False(ok)
+
+	expired := ErrSubscriptionExpired(42, time.Hour)
+	assertions.Equal(cErrors.UseCaseLevel, expired.GetLevel())
+	expiredFor, _ := ExpiredForFrom(expired)
+	assertions.Equal(time.Hour, expiredFor)
+}
+
+func TestGeneratedPath(t *testing.T) {
+	err := ErrUserNotFound(42)
+	_, fn, line, _ := runtime.Caller(0)
+	assert.Contains(t, err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that constructor frame is skipped")
+}
+
+func TestGeneratedTypes(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.Equal("PaymentRequired", PaymentRequired.String())
+	parsed, err := cErrors.ParseErrorType("PaymentRequired")
+	assertions.NoError(err)
+	assertions.Equal(PaymentRequired, parsed)
+
+	assertions.True(PaymentRequired.IsA(cErrors.ClientError))
+	assertions.Equal(402, PaymentRequired.HTTPStatus())
+	assertions.Equal(cErrors.GRPCFailedPrecondition, PaymentRequired.GRPCCode())
+	assertions.Equal(cErrors.ErrorMessage("Payment required"), PaymentRequired.PublicMessage())
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// generator renders Go code of catalog
+type generator struct {
+	Catalog
+	// Source is a name of catalog file
+	Source string
+	// Self is true if code is generated for the errors package itself
+	Self bool
+}
+
+// Generate returns formatted Go code of catalog
+func Generate(catalog Catalog, source string, self bool) ([]byte, error) {
+	g := generator{Catalog: catalog, Source: source, Self: self}
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, g); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, buf.String())
+	}
+	return code, nil
+}
+
+// Q qualifies identifier of errors package
+func (g generator) Q(name string) string {
+	if g.Self {
+		return name
+	}
+	return "cErrors." + name
+}
+
+// TypeRef returns reference to error type declared in catalog or to built-in one
+func (g generator) TypeRef(name string) string {
+	for _, t := range g.Types {
+		if t.Name == name {
+			return name
+		}
+	}
+	return g.Q(name)
+}
+
+// Imports returns imports of generated code, standard packages are followed by errors package
+func (g generator) Imports() []string {
+	var imports []string
+	if len(g.Enums) > 0 {
+		imports = append(imports, `"strconv"`)
+	}
+	for _, e := range g.Errors {
+		if len(e.Params) > 0 && placeholder.MatchString(e.Message) {
+			imports = append(imports, `"fmt"`)
+			break
+		}
+	}
+	for _, p := range g.Params() {
+		if strings.HasPrefix(p.Type, "time.") || strings.HasPrefix(p.Type, "*time.") || strings.HasPrefix(p.Type, "[]time.") {
+			imports = append(imports, `"time"`)
+			break
+		}
+	}
+	sort.Strings(imports)
+	if !g.Self && (len(g.Types) > 0 || len(g.Errors) > 0) {
+		if len(imports) > 0 {
+			imports = append(imports, "")
+		}
+		imports = append(imports, `cErrors "`+rootPackage+`"`)
+	}
+	return imports
+}
+
+// Params returns unique parameters of all errors in order of declaration
+func (g generator) Params() []Param {
+	var result []Param
+	seen := make(map[string]bool)
+	for _, e := range g.Errors {
+		for _, p := range e.Params {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+// TypesByDepth returns types where parents declared in catalog precede their children,
+// so info of parent is registered before it is inherited by children
+func (g generator) TypesByDepth() []Type {
+	types := append([]Type(nil), g.Types...)
+	sort.SliceStable(types, func(i, j int) bool {
+		return g.depth(types[i].Name) < g.depth(types[j].Name)
+	})
+	return types
+}
+
+// HasTypeInfo returns true if type overrides any attribute of type info
+func (t Type) HasTypeInfo() bool {
+	return t.Status != 0 || t.GRPC != 0 || t.Retryable != nil || t.Severity != ""
+}
+
+// Format returns format string and arguments of message template
+func (e Error) Format() (string, []string) {
+	var args []string
+	format := placeholder.ReplaceAllStringFunc(strings.ReplaceAll(e.Message, "%", "%%"), func(s string) string {
+		args = append(args, s[1:len(s)-1])
+		return "%v"
+	})
+	return format, args
+}
+
+// Message returns Go expression of error message
+func (g generator) Message(e Error) string {
+	format, args := e.Format()
+	if len(args) == 0 {
+		return strconv.Quote(e.Message)
+	}
+	return fmt.Sprintf("%s(fmt.Sprintf(%s, %s))", g.Q("ErrorMessage"), strconv.Quote(format), strings.Join(args, ", "))
+}
+
+// Signature returns parameters of constructor
+func (e Error) Signature() string {
+	params := make([]string, 0, len(e.Params))
+	for _, p := range e.Params {
+		params = append(params, p.Name+" "+p.Type)
+	}
+	return strings.Join(params, ", ")
+}
+
+var codeTemplate = template.Must(template.New("code").Funcs(template.FuncMap{
+	"quote":      strconv.Quote,
+	"exported":   exported,
+	"unexported": unexported,
+}).Parse(`// Code generated by errgen from {{.Source}}; DO NOT EDIT.
+
+package {{.Package}}
+{{with .Imports}}
+import (
+{{- range .}}
+{{- if .}}
+	{{.}}
+{{- else}}
+{{end}}
+{{- end}}
+)
+{{end}}
+{{- range .Enums}}
+{{- $enum := .}}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the errgen command to generate them again.
+	var x [1]struct{}
+{{- range $k, $v := .Values}}
+	_ = x[{{$v}}-{{$k}}]
+{{- end}}
+}
+
+// {{unexported .Type}}Names contain names of {{.Type}} values
+var {{unexported .Type}}Names = map[{{.Type}}]string{
+{{- range .Values}}
+	{{.}}: {{quote .}},
+{{- end}}
+}
+
+// String returns name of {{.Type}} value
+func (i {{.Type}}) String() string {
+	if name, ok := {{unexported .Type}}Names[i]; ok {
+		return name
+	}
+{{- with .Fallback}}
+	if name, ok := {{.}}(i); ok {
+		return name
+	}
+{{- end}}
+	return "{{.Type}}(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+{{end}}
+{{- with .Types}}
+// Error types
+const (
+{{- range .}}
+{{- with .Doc}}
+	// {{.}}
+{{- end}}
+	{{.Name}} = {{$.Q "ErrorType"}}({{.Value}})
+{{- end}}
+)
+
+func init() {
+{{- range .}}
+{{- $type := .}}
+	{{$.Q "SetTypeName"}}({{.Name}}, {{quote .Name}})
+{{- with .Parent}}
+	{{$.Q "SetTypeParent"}}({{$type.Name}}, {{$.TypeRef .}})
+{{- end}}
+{{- end}}
+}
+{{end}}
+{{- with .Params}}
+// Baggage keys of error parameters
+const (
+{{- range .}}
+	Baggage{{exported .Name}} = {{quote .Name}}
+{{- end}}
+)
+{{- range .}}
+
+// {{exported .Name}}From returns {{.Name}} parameter of error, the outermost value in the error chain is used
+func {{exported .Name}}From(err error) ({{.Type}}, bool) {
+	var value {{.Type}}
+	layer, ok := {{$.Q "FindInStack"}}(err, {{$.Q "HasBaggageKey"}}(Baggage{{exported .Name}}))
+	if ok {
+		value, ok = layer.GetBaggage()[Baggage{{exported .Name}}].({{.Type}})
+	}
+	return value, ok
+}
+{{- end}}
+{{end}}
+{{- with .Errors}}
+// Options of errors
+var (
+{{- range .}}
+	{{unexported .Name}}Options = {{$.Q "Options"}}(
+		{{$.Q "WithType"}}({{$.TypeRef .Type}}),
+		{{$.Q "WithCode"}}({{quote .Code}}),
+{{- with .Level}}
+		{{$.Q "WithLevel"}}({{$.Q .}}),
+{{- end}}
+{{- with .Severity}}
+		{{$.Q "WithSeverity"}}({{$.Q .}}),
+{{- end}}
+{{- with .Public}}
+		{{$.Q "WithPublicMessage"}}({{quote .}}),
+{{- end}}
+	)
+{{- end}}
+)
+
+// Sentinels of errors, they are compared with errors of the same code by errors.Is
+var (
+{{- range .}}
+	// {{.Name}} is a sentinel of {{.Code}} errors{{with .Doc}}, {{.}}{{end}}
+	{{.Name}} = {{$.Q "NewSentinel"}}({{quote .Code}}, {{$.TypeRef .Type}}, {{quote .Message}}, {{unexported .Name}}Options)
+{{- end}}
+)
+{{- range .}}
+
+// Err{{.Name}} create {{.Code}} error{{with .Doc}}, {{.}}{{end}}
+func Err{{.Name}}({{.Signature}}) {{$.Q "CustomError"}} {
+	return {{$.Q "Make"}}({{$.Message .}}, {{unexported .Name}}Options, {{$.Q "WithCallerSkip"}}(1)
+{{- with .Params}}, {{$.Q "WithBaggage"}}({{$.Q "ErrorBaggage"}}{
+{{- range .}}
+		Baggage{{exported .Name}}: {{.Name}},
+{{- end}}
+	})
+{{- end}})
+}
+{{- end}}
+{{end}}
+{{- range .TypesByDepth}}
+{{- if or .HasTypeInfo .Public}}
+{{- $type := .}}
+
+func init() {
+{{- if .HasTypeInfo}}
+	info := {{.Name}}.Info()
+{{- with .Status}}
+	info.HTTPStatus = {{.}}
+{{- end}}
+{{- with .GRPC}}
+	info.GRPCCode = {{$.Q "GRPCCode"}}({{.}})
+{{- end}}
+{{- with .Retryable}}
+	info.Retryable = {{.}}
+{{- end}}
+{{- with .Severity}}
+	info.Severity = {{$.Q .}}
+{{- end}}
+	{{$.Q "SetTypeInfo"}}({{.Name}}, info)
+{{- end}}
+{{- with .Public}}
+	{{$.Q "SetTypePublicMessage"}}({{$type.Name}}, {{quote .}})
+{{- end}}
+}
+{{- end}}
+{{- end}}
+`))
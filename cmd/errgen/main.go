@@ -0,0 +1,118 @@
+// Command errgen generates Go code and markdown reference of error catalog.
+//
+// Catalog is a YAML or JSON file with enums, own error types and domain errors:
+//
+//	package: users
+//	types:
+//	  - name: PaymentRequired
+//	    value: 100
+//	    parent: ClientError
+//	    status: 402
+//	errors:
+//	  - name: UserNotFound
+//	    code: user.not_found
+//	    type: NotFound
+//	    level: DataLevel
+//	    message: user {userID} not found
+//	    public: User not found
+//	    params:
+//	      - name: userID
+//	        type: int64
+//
+// For every error errgen generates a sentinel (UserNotFound) that is compared by errors.Is with errors of the same code,
+// a typed constructor (ErrUserNotFound(userID int64)) and typed baggage accessors (UserIDFrom(err)).
+// Own types are registered with their names, parents and HTTP/gRPC mapping. Enums get String method and table of names.
+//
+// Usage with go generate:
+//
+//	//go:generate go run github.com/Darevski/go-custom-errors/cmd/errgen -catalog errors.yaml -doc errors.md
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rootPackage is an import path of errors package
+const rootPackage = "github.com/Darevski/go-custom-errors"
+
+func main() {
+	catalogPath := flag.String("catalog", "", "path to YAML or JSON catalog")
+	output := flag.String("output", "", "path to generated Go file, <catalog>_gen.go by default")
+	doc := flag.String("doc", "", "path to generated markdown reference, it is not generated if empty")
+	flag.Parse()
+
+	if err := run(*catalogPath, *output, *doc); err != nil {
+		fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(catalogPath, output, doc string) error {
+	if catalogPath == "" {
+		return fmt.Errorf("-catalog flag is required")
+	}
+	catalog, err := LoadCatalog(catalogPath)
+	if err != nil {
+		return err
+	}
+	if catalog.Package == "" {
+		catalog.Package = os.Getenv("GOPACKAGE")
+	}
+	if catalog.Package == "" {
+		return fmt.Errorf("package name is not set in catalog and $GOPACKAGE is empty")
+	}
+	if output == "" {
+		output = strings.TrimSuffix(catalogPath, filepath.Ext(catalogPath)) + "_gen.go"
+	}
+
+	self, err := isRootPackage(filepath.Dir(output))
+	if err != nil {
+		return err
+	}
+	code, err := Generate(catalog, filepath.Base(catalogPath), self)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(output, code, 0o644); err != nil {
+		return err
+	}
+	if doc != "" {
+		return os.WriteFile(doc, Markdown(catalog, filepath.Base(catalogPath)), 0o644)
+	}
+	return nil
+}
+
+// isRootPackage returns true if directory belongs to errors package, import path of directory is resolved by go.mod
+func isRootPackage(dir string) (bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+	for current := dir; ; current = filepath.Dir(current) {
+		data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return false, err
+			}
+			return modulePath(data) == rootPackage && rel == ".", nil
+		}
+		if filepath.Dir(current) == current {
+			return false, nil
+		}
+	}
+}
+
+// modulePath returns module path of go.mod file
+func modulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
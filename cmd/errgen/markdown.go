@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Markdown returns reference of all types, errors and enums of catalog
+func Markdown(catalog Catalog, source string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<!-- Code generated by errgen from %s; DO NOT EDIT. -->\n\n", source)
+	fmt.Fprintf(&buf, "# Errors of %s package\n", catalog.Package)
+
+	if len(catalog.Errors) > 0 {
+		buf.WriteString("\n## Errors\n\n")
+		table(&buf, []string{"Code", "Name", "Type", "HTTP status", "Level", "Severity", "Message", "Public message", "Parameters"})
+		for _, e := range catalog.Errors {
+			params := make([]string, 0, len(e.Params))
+			for _, p := range e.Params {
+				params = append(params, fmt.Sprintf("`%s %s`", p.Name, p.Type))
+			}
+			row(&buf, "`"+e.Code+"`", e.Name, e.Type, fmt.Sprint(catalog.status(e.Type)), or(e.Level, "DefaultLevel"),
+				or(e.Severity, "DefaultSeverity"), e.Message, e.Public, strings.Join(params, ", "))
+		}
+	}
+
+	if len(catalog.Types) > 0 {
+		buf.WriteString("\n## Types\n\n")
+		table(&buf, []string{"Type", "Value", "Parent", "HTTP status", "gRPC code", "Retryable", "Severity", "Public message", "Description"})
+		for _, t := range catalog.Types {
+			info := catalog.info(t.Name)
+			row(&buf, t.Name, fmt.Sprint(t.Value), t.Parent, fmt.Sprint(info.HTTPStatus), fmt.Sprint(info.GRPCCode),
+				fmt.Sprint(info.Retryable), info.Severity, t.Public, t.Doc)
+		}
+	}
+
+	for _, enum := range catalog.Enums {
+		fmt.Fprintf(&buf, "\n## %s\n\n", enum.Type)
+		if enum.Type != "ErrorType" {
+			table(&buf, []string{"Name", "Value"})
+			for k, value := range enum.Values {
+				row(&buf, value, fmt.Sprint(k))
+			}
+			continue
+		}
+		table(&buf, []string{"Name", "Value", "Parent", "HTTP status", "gRPC code", "Retryable", "Severity"})
+		for k, value := range enum.Values {
+			parent := ""
+			if k < len(builtinTypes) {
+				parent = builtinTypes[k].Parent
+			}
+			info := builtinInfo(k, "")
+			row(&buf, value, fmt.Sprint(k), parent, fmt.Sprint(info.HTTPStatus), fmt.Sprint(info.GRPCCode),
+				fmt.Sprint(info.Retryable), info.Severity)
+		}
+	}
+	return buf.Bytes()
+}
+
+func table(buf *bytes.Buffer, header []string) {
+	row(buf, header...)
+	separators := make([]string, len(header))
+	for k := range separators {
+		separators[k] = "---"
+	}
+	row(buf, separators...)
+}
+
+func row(buf *bytes.Buffer, cells ...string) {
+	for k, cell := range cells {
+		cells[k] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintf(buf, "| %s |\n", strings.Join(cells, " | "))
+}
+
+func or(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
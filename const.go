@@ -1,12 +1,14 @@
-// Code generated by "stringer -type=ErrorType,ErrorLevel,ErrorSeverity -output=const.go"; DO NOT EDIT.
+// Code generated by errgen from errgen.yaml; DO NOT EDIT.
 
 package errors
 
-import "strconv"
+import (
+	"strconv"
+)
 
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
-	// Re-run the stringer command to generate them again.
+	// Re-run the errgen command to generate them again.
 	var x [1]struct{}
 	_ = x[DefaultType-0]
 	_ = x[NotFound-1]
@@ -15,21 +17,55 @@ func _() {
 	_ = x[BadRequest-4]
 	_ = x[AccessDenied-5]
 	_ = x[Unauthorized-6]
+	_ = x[ClientError-7]
+	_ = x[ServerError-8]
+	_ = x[Conflict-9]
+	_ = x[AlreadyExists-10]
+	_ = x[PreconditionFailed-11]
+	_ = x[RateLimited-12]
+	_ = x[Canceled-13]
+	_ = x[Timeout-14]
+	_ = x[Unavailable-15]
+	_ = x[NotImplemented-16]
+	_ = x[DataLoss-17]
 }
 
-const _ErrorType_name = "DefaultTypeNotFoundInvalidArgumentsInternalErrorBadRequestAccessDeniedUnauthorized"
-
-var _ErrorType_index = [...]uint8{0, 11, 19, 35, 48, 58, 70, 82}
+// errorTypeNames contain names of ErrorType values
+var errorTypeNames = map[ErrorType]string{
+	DefaultType:        "DefaultType",
+	NotFound:           "NotFound",
+	InvalidArguments:   "InvalidArguments",
+	InternalError:      "InternalError",
+	BadRequest:         "BadRequest",
+	AccessDenied:       "AccessDenied",
+	Unauthorized:       "Unauthorized",
+	ClientError:        "ClientError",
+	ServerError:        "ServerError",
+	Conflict:           "Conflict",
+	AlreadyExists:      "AlreadyExists",
+	PreconditionFailed: "PreconditionFailed",
+	RateLimited:        "RateLimited",
+	Canceled:           "Canceled",
+	Timeout:            "Timeout",
+	Unavailable:        "Unavailable",
+	NotImplemented:     "NotImplemented",
+	DataLoss:           "DataLoss",
+}
 
+// String returns name of ErrorType value
 func (i ErrorType) String() string {
-	if i >= ErrorType(len(_ErrorType_index)-1) {
-		return "ErrorType(" + strconv.FormatInt(int64(i), 10) + ")"
+	if name, ok := errorTypeNames[i]; ok {
+		return name
+	}
+	if name, ok := typeName(i); ok {
+		return name
 	}
-	return _ErrorType_name[_ErrorType_index[i]:_ErrorType_index[i+1]]
+	return "ErrorType(" + strconv.FormatInt(int64(i), 10) + ")"
 }
+
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
-	// Re-run the stringer command to generate them again.
+	// Re-run the errgen command to generate them again.
 	var x [1]struct{}
 	_ = x[DefaultLevel-0]
 	_ = x[DataLevel-1]
@@ -39,19 +75,27 @@ func _() {
 	_ = x[TransportLevel-5]
 }
 
-const _ErrorLevel_name = "DefaultLevelDataLevelUseCaseLevelContainerLevelControllerLevelTransportLevel"
-
-var _ErrorLevel_index = [...]uint8{0, 12, 21, 33, 47, 62, 76}
+// errorLevelNames contain names of ErrorLevel values
+var errorLevelNames = map[ErrorLevel]string{
+	DefaultLevel:    "DefaultLevel",
+	DataLevel:       "DataLevel",
+	UseCaseLevel:    "UseCaseLevel",
+	ContainerLevel:  "ContainerLevel",
+	ControllerLevel: "ControllerLevel",
+	TransportLevel:  "TransportLevel",
+}
 
+// String returns name of ErrorLevel value
 func (i ErrorLevel) String() string {
-	if i < 0 || i >= ErrorLevel(len(_ErrorLevel_index)-1) {
-		return "ErrorLevel(" + strconv.FormatInt(int64(i), 10) + ")"
+	if name, ok := errorLevelNames[i]; ok {
+		return name
 	}
-	return _ErrorLevel_name[_ErrorLevel_index[i]:_ErrorLevel_index[i+1]]
+	return "ErrorLevel(" + strconv.FormatInt(int64(i), 10) + ")"
 }
+
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
-	// Re-run the stringer command to generate them again.
+	// Re-run the errgen command to generate them again.
 	var x [1]struct{}
 	_ = x[DefaultSeverity-0]
 	_ = x[Debug-1]
@@ -62,13 +106,21 @@ func _() {
 	_ = x[Panic-6]
 }
 
-const _ErrorSeverity_name = "DefaultSeverityDebugInfoWarningCriticalFatalPanic"
-
-var _ErrorSeverity_index = [...]uint8{0, 15, 20, 24, 31, 39, 44, 49}
+// errorSeverityNames contain names of ErrorSeverity values
+var errorSeverityNames = map[ErrorSeverity]string{
+	DefaultSeverity: "DefaultSeverity",
+	Debug:           "Debug",
+	Info:            "Info",
+	Warning:         "Warning",
+	Critical:        "Critical",
+	Fatal:           "Fatal",
+	Panic:           "Panic",
+}
 
+// String returns name of ErrorSeverity value
 func (i ErrorSeverity) String() string {
-	if i < 0 || i >= ErrorSeverity(len(_ErrorSeverity_index)-1) {
-		return "ErrorSeverity(" + strconv.FormatInt(int64(i), 10) + ")"
+	if name, ok := errorSeverityNames[i]; ok {
+		return name
 	}
-	return _ErrorSeverity_name[_ErrorSeverity_index[i]:_ErrorSeverity_index[i+1]]
+	return "ErrorSeverity(" + strconv.FormatInt(int64(i), 10) + ")"
 }
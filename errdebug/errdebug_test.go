@@ -0,0 +1,190 @@
+package errdebug
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var referenceTime = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// testRing create ring with clock that moves forward for a minute on every call
+func testRing(size int) *Ring {
+	ring := NewRing(size)
+	current := referenceTime
+	ring.now = func() time.Time {
+		current = current.Add(time.Minute)
+		return current
+	}
+	return ring
+}
+
+func TestRing_Record(t *testing.T) {
+	assertions := assert.New(t)
+	ring := testRing(3)
+	assertions.Equal(0, ring.Len())
+
+	for k := 0; k < 5; k++ {
+		ring.Record(cErrors.NewBase(cErrors.ErrorMessage(fmt.Sprintf("%d error", k))))
+	}
+	ring.Record(nil)
+	assertions.Equal(3, ring.Len(), "Check that ring is bounded")
+
+	entries := ring.Entries(Filter{})
+	assertions.Len(entries, 3)
+	for k, v := range []string{"4 error", "3 error", "2 error"} {
+		assertions.Equal(v, entries[k].Err.Error(), "Check that the newest errors go first (%d)", k)
+	}
+
+	ring.Reset()
+	assertions.Equal(0, ring.Len())
+	assertions.Empty(ring.Entries(Filter{}))
+}
+
+func TestRing_EntriesFilter(t *testing.T) {
+	assertions := assert.New(t)
+	ring := testRing(10)
+
+	ring.Record(cErrors.NotFound.New(cErrors.DataLevel, nil, cErrors.Info, "not found"))
+	ring.Record(cErrors.InternalError.New(cErrors.UseCaseLevel, nil, cErrors.Critical, "internal"))
+	ring.Record(cErrors.NotFound.New(cErrors.ControllerLevel, nil, cErrors.Warning, "not found 2"))
+
+	assertions.Len(ring.Entries(Filter{Types: []cErrors.ErrorType{cErrors.NotFound}}), 2, "Check type filter")
+	assertions.Len(ring.Entries(Filter{Levels: []cErrors.ErrorLevel{cErrors.UseCaseLevel}}), 1, "Check level filter")
+	assertions.Len(ring.Entries(Filter{Severities: []cErrors.ErrorSeverity{cErrors.Info, cErrors.Warning}}), 2, "Check severity filter")
+	assertions.Len(ring.Entries(Filter{Limit: 1}), 1, "Check limit")
+	assertions.Len(ring.Entries(Filter{Since: referenceTime.Add(2 * time.Minute)}), 2, "Check since filter")
+	assertions.Len(ring.Entries(Filter{Until: referenceTime.Add(2 * time.Minute)}), 2, "Check until filter")
+
+	first := ring.Entries(Filter{})[2]
+	assertions.Len(ring.Entries(Filter{Fingerprint: first.Fingerprint}), 1, "Check fingerprint filter")
+}
+
+func TestParseFilter(t *testing.T) {
+	assertions := assert.New(t)
+
+	query := map[string][]string{
+		"type":     {"NotFound", "3"},
+		"level":    {"DataLevel"},
+		"severity": {"warning"},
+		"since":    {"15m"},
+		"until":    {"2020-01-01T12:00:00Z"},
+		"limit":    {"5"},
+	}
+	filter, err := ParseFilter(query, referenceTime)
+	assertions.NoError(err)
+	assertions.Equal([]cErrors.ErrorType{cErrors.NotFound, cErrors.InternalError}, filter.Types)
+	assertions.Equal([]cErrors.ErrorLevel{cErrors.DataLevel}, filter.Levels)
+	assertions.Equal([]cErrors.ErrorSeverity{cErrors.Warning}, filter.Severities)
+	assertions.Equal(referenceTime.Add(-15*time.Minute), filter.Since)
+	assertions.Equal(referenceTime, filter.Until)
+	assertions.Equal(5, filter.Limit)
+
+	_, err = ParseFilter(map[string][]string{"type": {"Unknown"}}, referenceTime)
+	assertions.Error(err, "Check unknown type")
+	_, err = ParseFilter(map[string][]string{"since": {"yesterday"}}, referenceTime)
+	assertions.Error(err, "Check invalid time")
+}
+
+func TestHandler(t *testing.T) {
+	assertions := assert.New(t)
+	ring := testRing(10)
+	ring.Record(cErrors.Wrap(cErrors.NotFound.NewBase("user not found"), "load user"))
+	ring.Record(cErrors.InternalError.NewBase("<script>"))
+	handler := Handler(ring)
+
+	request := func(target string, accept string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.Header.Set("Accept", accept)
+		handler.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	response := request(Path+"?type=NotFound&format=json", "")
+	assertions.Equal(http.StatusOK, response.Code)
+	var entries []jsonEntry
+	assertions.NoError(json.Unmarshal(response.Body.Bytes(), &entries))
+	assertions.Len(entries, 1)
+	assertions.Equal("load user: user not found", entries[0].Error.Error)
+	assertions.Len(entries[0].Error.Stack, 2)
+	assertions.Len(entries[0].Trace, 3, "Check that the full trace slice is served")
+
+	response = request(Path, "text/html")
+	assertions.Contains(response.Header().Get("Content-Type"), "text/html")
+	assertions.Contains(response.Body.String(), "&lt;script&gt;", "Check html escaping")
+
+	response = request(Path, "")
+	assertions.Contains(response.Header().Get("Content-Type"), "text/plain")
+	assertions.True(strings.HasPrefix(response.Body.String(), "2 errors"))
+	assertions.Contains(response.Body.String(), "type=NotFound level=DefaultLevel severity=DefaultSeverity")
+
+	response = request(Path+"?level=Unknown", "")
+	assertions.Equal(http.StatusBadRequest, response.Code)
+
+	// NaN could not be encoded into JSON
+	ring.Record(cErrors.NewValidation().Add("ratio", "min", "must be positive", math.NaN()).Err())
+	response = request(Path+"?format=json", "")
+	assertions.Equal(http.StatusOK, response.Code, "Check that entry that could not be encoded does not break the list")
+	entries = nil
+	assertions.NoError(json.Unmarshal(response.Body.Bytes(), &entries))
+	if assertions.Len(entries, 3) {
+		assertions.Equal("validation failed for ratio", entries[0].Error.Error)
+		assertions.Equal("<script>", entries[1].Error.Error)
+		assertions.Len(entries[1].Error.Stack, 1, "Check that other entries are intact")
+		assertions.Len(entries[2].Error.Stack, 2)
+	}
+
+	response = request(Path+"?format=xml", "")
+	assertions.Equal(http.StatusBadRequest, response.Code, "Check unknown format")
+}
+
+func TestFailpointsHandler(t *testing.T) {
+	assertions := assert.New(t)
+	defer cErrors.DisableFailpoints()
+	handler := FailpointsHandler()
+
+	request := func(method, target string) ([]jsonFailpoint, *httptest.ResponseRecorder) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+		var result []jsonFailpoint
+		if recorder.Code == http.StatusOK {
+			assertions.NoError(json.Unmarshal(recorder.Body.Bytes(), &result))
+		}
+		return result, recorder
+	}
+
+	failpoints, _ := request(http.MethodPost, FailpointsPath+"?name=repo.user.load&type=Unavailable&level=DataLevel&severity=Critical&count=1")
+	assertions.Equal([]jsonFailpoint{{Name: "repo.user.load", Type: "Unavailable", Level: "DataLevel", Severity: "Critical", Count: 1}}, failpoints)
+	_, _ = request(http.MethodPut, FailpointsPath+"?name=repo.order.load&probability=0.5&message=boom")
+
+	err := cErrors.Inject("repo.user.load")
+	if assertions.Error(err) {
+		assertions.Equal(cErrors.Unavailable, err.GetType())
+	}
+	failpoints, _ = request(http.MethodGet, FailpointsPath)
+	if assertions.Len(failpoints, 2) {
+		assertions.Equal("boom", failpoints[0].Message)
+		assertions.Equal(1, failpoints[1].Hits)
+	}
+
+	failpoints, _ = request(http.MethodDelete, FailpointsPath+"?name=repo.order.load")
+	assertions.Len(failpoints, 1)
+	failpoints, _ = request(http.MethodDelete, FailpointsPath)
+	assertions.Empty(failpoints)
+
+	for _, target := range []string{"?type=NotFound", "?name=x&type=Unknown", "?name=x&probability=2", "?name=x&probability=0", "?name=x&count=many"} {
+		_, response := request(http.MethodPost, FailpointsPath+target)
+		assertions.Equal(http.StatusBadRequest, response.Code, target)
+	}
+	_, response := request(http.MethodPatch, FailpointsPath)
+	assertions.Equal(http.StatusMethodNotAllowed, response.Code)
+}
@@ -0,0 +1,134 @@
+package errdebug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// FailpointsPath is a suggested path of FailpointsHandler
+const FailpointsPath = "/debug/failpoints"
+
+// jsonFailpoint is JSON representation of cErrors.FailpointState
+type jsonFailpoint struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Level       string  `json:"level"`
+	Severity    string  `json:"severity"`
+	Message     string  `json:"message,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+	Count       int     `json:"count,omitempty"`
+	Hits        int     `json:"hits"`
+}
+
+// FailpointsHandler returns http.Handler that manages failpoints (see errors.Inject), enabled failpoints
+// are returned in JSON for every request:
+//
+//	GET                 - list enabled failpoints
+//	POST, PUT           - enable failpoint, parameters are described in ParseFailpoint
+//	DELETE ?name=<name> - disable failpoint, all failpoints are disabled if name is not set
+//
+// The handler is not registered in http.DefaultServeMux since it changes behaviour of the service,
+// it should be mounted explicitly, e.g. only for chaos runs
+//
+//	mux.Handle(errdebug.FailpointsPath, errdebug.FailpointsHandler())
+func FailpointsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			name, failpoint, err := ParseFailpoint(r.Form)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			cErrors.EnableFailpoint(name, failpoint)
+		case http.MethodDelete:
+			if name := r.URL.Query().Get("name"); name != "" {
+				cErrors.DisableFailpoint(name)
+			} else {
+				cErrors.DisableFailpoints()
+			}
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST, PUT, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		states := cErrors.Failpoints()
+		result := make([]jsonFailpoint, 0, len(states))
+		for _, v := range states {
+			result = append(result, jsonFailpoint{
+				Name:        v.Name,
+				Type:        v.Type.String(),
+				Level:       v.Level.String(),
+				Severity:    v.Severity.String(),
+				Message:     v.Message.String(),
+				Probability: v.Probability,
+				Count:       v.Count,
+				Hits:        v.Hits,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(result)
+	})
+}
+
+// ParseFailpoint builds failpoint from the url query, supported parameters are:
+//
+//	name                  - name of failpoint, required
+//	type, level, severity - names or codes
+//	message               - message of error
+//	probability           - probability of error in range (0, 1], error is returned on every call if it is not set
+//	count                 - max count of errors
+func ParseFailpoint(query url.Values) (string, cErrors.Failpoint, error) {
+	var failpoint cErrors.Failpoint
+	name := query.Get("name")
+	if name == "" {
+		return "", failpoint, fmt.Errorf("name of failpoint is required")
+	}
+
+	var err error
+	if value := query.Get("type"); value != "" {
+		if failpoint.Type, err = cErrors.ParseErrorType(value); err != nil {
+			return "", failpoint, err
+		}
+	}
+	if value := query.Get("level"); value != "" {
+		if failpoint.Level, err = cErrors.ParseErrorLevel(value); err != nil {
+			return "", failpoint, err
+		}
+	}
+	if value := query.Get("severity"); value != "" {
+		if failpoint.Severity, err = cErrors.ParseErrorSeverity(value); err != nil {
+			return "", failpoint, err
+		}
+	}
+	failpoint.Message = cErrors.ErrorMessage(query.Get("message"))
+	if value := query.Get("probability"); value != "" {
+		if failpoint.Probability, err = strconv.ParseFloat(value, 64); err != nil {
+			return "", failpoint, fmt.Errorf("invalid probability: %w", err)
+		}
+		// zero probability of Failpoint means that error is always returned, so it could not be set explicitly
+		if failpoint.Probability <= 0 || failpoint.Probability > 1 {
+			return "", failpoint, fmt.Errorf("invalid probability %v, value in range (0, 1] expected", failpoint.Probability)
+		}
+	}
+	if value := query.Get("count"); value != "" {
+		if failpoint.Count, err = strconv.Atoi(value); err != nil {
+			return "", failpoint, fmt.Errorf("invalid count: %w", err)
+		}
+	}
+	return name, failpoint, nil
+}
@@ -0,0 +1,131 @@
+package errdebug
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Filter describes which entries should be returned from the Ring,
+// empty fields are not taken into account
+type Filter struct {
+	Types       []cErrors.ErrorType
+	Levels      []cErrors.ErrorLevel
+	Severities  []cErrors.ErrorSeverity
+	Fingerprint string
+	// Since and Until bound the time window of error recording
+	Since time.Time
+	Until time.Time
+	// Limit is a max count of returned entries
+	Limit int
+}
+
+// Match checks that entry satisfies all filter conditions
+func (f Filter) Match(entry Entry) bool {
+	if len(f.Types) > 0 && !containsType(f.Types, entry.Err.GetType()) {
+		return false
+	}
+	if len(f.Levels) > 0 && !containsLevel(f.Levels, entry.Err.GetLevel()) {
+		return false
+	}
+	if len(f.Severities) > 0 && !containsSeverity(f.Severities, entry.Err.GetSeverity()) {
+		return false
+	}
+	if f.Fingerprint != "" && f.Fingerprint != entry.Fingerprint {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// ParseFilter builds Filter from the url query, supported parameters are:
+//
+//	type, level, severity - names or codes, could be repeated
+//	fingerprint           - error fingerprint
+//	since, until          - RFC3339 time or duration before now (e.g. 15m)
+//	limit                 - max count of errors
+func ParseFilter(query url.Values, now time.Time) (Filter, error) {
+	var filter Filter
+	for _, v := range query["type"] {
+		errType, err := cErrors.ParseErrorType(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Types = append(filter.Types, errType)
+	}
+	for _, v := range query["level"] {
+		level, err := cErrors.ParseErrorLevel(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Levels = append(filter.Levels, level)
+	}
+	for _, v := range query["severity"] {
+		severity, err := cErrors.ParseErrorSeverity(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Severities = append(filter.Severities, severity)
+	}
+	filter.Fingerprint = query.Get("fingerprint")
+
+	var err error
+	if filter.Since, err = parseTime(query.Get("since"), now); err != nil {
+		return filter, fmt.Errorf("invalid since: %w", err)
+	}
+	if filter.Until, err = parseTime(query.Get("until"), now); err != nil {
+		return filter, fmt.Errorf("invalid until: %w", err)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return filter, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// parseTime parses RFC3339 time or duration that is subtracted from now
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func containsType(types []cErrors.ErrorType, target cErrors.ErrorType) bool {
+	for _, v := range types {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLevel(levels []cErrors.ErrorLevel, target cErrors.ErrorLevel) bool {
+	for _, v := range levels {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeverity(severities []cErrors.ErrorSeverity, target cErrors.ErrorSeverity) bool {
+	for _, v := range severities {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
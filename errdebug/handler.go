@@ -0,0 +1,160 @@
+package errdebug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Path is a path of handler registered in http.DefaultServeMux
+const Path = "/debug/errors"
+
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+func init() {
+	http.Handle(Path, Handler(DefaultRing))
+}
+
+// jsonEntry is JSON representation of Entry
+type jsonEntry struct {
+	Time  time.Time           `json:"time"`
+	Error cErrors.ErrorRecord `json:"error"`
+	Trace []string            `json:"trace"`
+}
+
+// Handler returns http.Handler that serves errors of the ring.
+// Query parameters described in ParseFilter are used for errors filtering,
+// output format is chosen by "format" parameter (html, json, text) or by Accept header, unknown formats are rejected
+func Handler(ring *Ring) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := ParseFilter(r.URL.Query(), ring.now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		entries := ring.Entries(filter)
+
+		// body is rendered before writing, so rendering error could be returned with its own status
+		var body bytes.Buffer
+		var contentType string
+		switch format(r) {
+		case FormatJSON:
+			contentType = "application/json; charset=utf-8"
+			err = writeJSON(&body, entries)
+		case FormatHTML:
+			contentType = "text/html; charset=utf-8"
+			err = htmlTemplate.Execute(&body, entries)
+		case FormatText:
+			contentType = "text/plain; charset=utf-8"
+			err = writeText(&body, entries)
+		default:
+			http.Error(w, fmt.Sprintf("unknown format %q, html, json or text expected", format(r)), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		_, _ = body.WriteTo(w)
+	})
+}
+
+// format returns output format based on request
+func format(r *http.Request) string {
+	if value := r.URL.Query().Get("format"); value != "" {
+		return value
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/html"):
+		return FormatHTML
+	case strings.Contains(accept, "application/json"):
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// writeJSON writes entries in JSON, entries are encoded one by one, so entry that could not be encoded
+// is replaced by its error string and does not break the whole list
+func writeJSON(w io.Writer, entries []Entry) error {
+	result := make([]json.RawMessage, 0, len(entries))
+	for _, v := range entries {
+		entry := jsonEntry{
+			Time:  v.Time,
+			Error: cErrors.NewErrorRecord(v.Err),
+			Trace: v.Err.GetTraceSlice(),
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			entry.Error = cErrors.ErrorRecord{Fingerprint: v.Fingerprint, Error: v.Err.Error()}
+			if data, err = json.Marshal(entry); err != nil {
+				return err
+			}
+		}
+		result = append(result, data)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func writeText(w io.Writer, entries []Entry) error {
+	if _, err := fmt.Fprintf(w, "%d errors\n", len(entries)); err != nil {
+		return err
+	}
+	for _, v := range entries {
+		_, err := fmt.Fprintf(w, "\n%s %s type=%s level=%s severity=%s\n%s\n",
+			v.Time.Format(time.RFC3339Nano), v.Fingerprint,
+			v.Err.GetType(), v.Err.GetLevel(), v.Err.GetSeverity(), v.Err.Error(),
+		)
+		if err != nil {
+			return err
+		}
+		for _, line := range v.Err.GetTraceSlice() {
+			if _, err = fmt.Fprintf(w, "\t%s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var htmlTemplate = template.Must(template.New("errors").Parse(`<html>
+<head>
+<title>/debug/errors</title>
+<style>
+body { font-family: monospace; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 4px; vertical-align: top; text-align: left; }
+</style>
+</head>
+<body>
+<p>{{len .}} errors</p>
+<table>
+<tr><th>Time</th><th>Fingerprint</th><th>Type</th><th>Level</th><th>Severity</th><th>Error</th></tr>
+{{range .}}<tr>
+<td>{{.Time.Format "2006-01-02T15:04:05.000Z07:00"}}</td>
+<td><a href="?fingerprint={{.Fingerprint}}&format=html">{{.Fingerprint}}</a></td>
+<td>{{.Err.GetType}}</td>
+<td>{{.Err.GetLevel}}</td>
+<td>{{.Err.GetSeverity}}</td>
+<td>{{.Err.Error}}<pre>{{range .Err.GetTraceSlice}}{{.}}
+{{end}}</pre></td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
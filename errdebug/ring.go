@@ -0,0 +1,123 @@
+// Package errdebug keeps recently reported custom errors in a bounded in-process ring buffer
+// and serves them over HTTP, similar to net/http/pprof.
+//
+// The package is typically only imported for the side effect of registering its HTTP handler.
+// The handled path is /debug/errors.
+//
+//	import _ "github.com/Darevski/go-custom-errors/errdebug"
+//
+// Errors are added to the buffer with Record function
+//
+//	if err != nil {
+//		errdebug.Record(err)
+//	}
+package errdebug
+
+import (
+	"sync"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// DefaultSize is a capacity of DefaultRing
+const DefaultSize = 256
+
+// DefaultRing is the ring buffer used by Record function and by the /debug/errors handler
+var DefaultRing = NewRing(DefaultSize)
+
+// Record adds error into the DefaultRing
+func Record(err cErrors.CustomError) {
+	DefaultRing.Record(err)
+}
+
+// Entry is a single recorded error
+type Entry struct {
+	// Time of error recording
+	Time time.Time
+	// Fingerprint of error, see errors.Fingerprint
+	Fingerprint string
+	// Err is recorded error
+	Err cErrors.CustomError
+}
+
+// Ring is a bounded buffer of recently recorded errors, after reaching the capacity
+// the oldest errors are overwritten by new ones. It is safe for concurrent use
+type Ring struct {
+	mu      sync.Mutex
+	entries []Entry
+	// next is a position for the next recorded entry
+	next int
+	// full is true when the buffer has been overwritten at least once
+	full bool
+	// now returns current time, could be replaced in tests
+	now func() time.Time
+}
+
+// NewRing create ring buffer with specified capacity, capacity less than 1 is replaced by 1
+func NewRing(size int) *Ring {
+	if size < 1 {
+		size = 1
+	}
+	return &Ring{entries: make([]Entry, size), now: time.Now}
+}
+
+// Record adds error into the buffer, nil errors are ignored
+func (r *Ring) Record(err cErrors.CustomError) {
+	if err == nil {
+		return
+	}
+	entry := Entry{Fingerprint: cErrors.Fingerprint(err), Err: err}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry.Time = r.now()
+	r.entries[r.next] = entry
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Entries return recorded errors that match filter, the newest errors go first
+func (r *Ring) Entries(filter Filter) []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := r.next
+	if r.full {
+		count = len(r.entries)
+	}
+	result := make([]Entry, 0, count)
+	for i := 1; i <= count; i++ {
+		entry := r.entries[(r.next-i+len(r.entries))%len(r.entries)]
+		if !filter.Match(entry) {
+			continue
+		}
+		result = append(result, entry)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// Len returns count of errors stored in the buffer
+func (r *Ring) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.full {
+		return len(r.entries)
+	}
+	return r.next
+}
+
+// Reset removes all errors from the buffer
+func (r *Ring) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make([]Entry, len(r.entries))
+	r.next = 0
+	r.full = false
+}
@@ -43,12 +43,16 @@ func NewBaseF(format string, args ...interface{}) CustomError {
 }
 
 // Wrap error with message. If wrapped error implements CustomError interface than all semantic data such
-// a severity, error level, error type will be copied into result error
+// a severity, error level, error type will be copied into result error, otherwise type and baggage could be
+// set up by translators (see SetTranslateOnWrap)
 func Wrap(err error, message ErrorMessage) CustomError {
 	wrappedErr := errs.Wrap(err, message.String())
 	if customErr, ok := err.(CustomError); ok {
 		return newCustomErr(customErr.GetType(), make(ErrorBaggage), customErr.GetLevel(), customErr.GetSeverity(), wrappedErr)
 	}
+	if errType, baggage, ok := translateOnWrap(err); ok {
+		return newCustomErr(errType, baggage, DefaultLevel, DefaultSeverity, wrappedErr)
+	}
 	return newCustomErr(DefaultType, make(ErrorBaggage), DefaultLevel, DefaultSeverity, wrappedErr)
 }
 
@@ -58,6 +62,9 @@ func WrapF(err error, format string, args ...interface{}) CustomError {
 	if customErr, ok := err.(CustomError); ok {
 		return newCustomErr(customErr.GetType(), make(ErrorBaggage), customErr.GetLevel(), customErr.GetSeverity(), wrappedErr)
 	}
+	if errType, baggage, ok := translateOnWrap(err); ok {
+		return newCustomErr(errType, baggage, DefaultLevel, DefaultSeverity, wrappedErr)
+	}
 	return newCustomErr(DefaultType, make(ErrorBaggage), DefaultLevel, DefaultSeverity, wrappedErr)
 }
 
@@ -81,6 +88,11 @@ type customErr struct {
 	// Original/Wrapped error
 	//wrappedErr Unwrapped
 	wrappedErr error
+	// Stable code of sentinel error, it is inherited by errors that wrap sentinel
+	code ErrorCode
+	// Message and code that could be shown to clients
+	publicMessage ErrorMessage
+	publicCode    ErrorCode
 }
 
 type ErrorMessage string
@@ -91,13 +103,24 @@ func (m ErrorMessage) String() string {
 
 type ErrorPath string
 
+type ErrorCode string
+
+func (c ErrorCode) String() string {
+	return string(c)
+}
+
 func (p ErrorPath) String() string {
 	return string(p)
 }
 
-// newCustomErr is constructor for customErr struct
+// newCustomErr is constructor for customErr struct, default severity and level are replaced according to policies
 func newCustomErr(errType ErrorType, baggage ErrorBaggage, dataLayer ErrorLevel, severity ErrorSeverity, originalErr error) *customErr {
-	return &customErr{errType: errType, baggage: baggage, level: dataLayer, severity: severity, wrappedErr: originalErr}
+	e := &customErr{errType: errType, baggage: baggage, level: dataLayer, severity: severity, wrappedErr: originalErr}
+	if wrapped, ok := e.Unwrap().(CustomError); ok {
+		e.code = wrapped.GetCode()
+	}
+	e.applyPolicies()
+	return e
 }
 
 // GetLevel returns the error level based on the data level at which the error occurred
@@ -116,6 +139,11 @@ func (e *customErr) GetType() ErrorType {
 	return e.errType
 }
 
+// GetCode return code of sentinel error that is wrapped by error or empty code
+func (e *customErr) GetCode() ErrorCode {
+	return e.code
+}
+
 // GetSeverity return severity value
 func (e *customErr) GetSeverity() ErrorSeverity {
 	return e.severity
@@ -137,6 +165,28 @@ func (e *customErr) GetMessage() ErrorMessage {
 	return ErrorMessage(errMessage)
 }
 
+// GetPublicMessage return message that could be shown to clients, see PublicMessage
+func (e *customErr) GetPublicMessage() ErrorMessage {
+	return e.publicMessage
+}
+
+// SetPublicMessage set message that could be shown to clients
+func (e *customErr) SetPublicMessage(message ErrorMessage) CustomError {
+	e.publicMessage = message
+	return e
+}
+
+// GetPublicCode return code that could be shown to clients, see PublicCode
+func (e *customErr) GetPublicCode() ErrorCode {
+	return e.publicCode
+}
+
+// SetPublicCode set code that could be shown to clients
+func (e *customErr) SetPublicCode(code ErrorCode) CustomError {
+	e.publicCode = code
+	return e
+}
+
 // GetBaggage return baggage of error
 func (e *customErr) GetBaggage() ErrorBaggage {
 	return e.baggage
@@ -212,14 +262,31 @@ func Cause(e CustomError) error {
 	}
 }
 
-// Is - is the function that is used to compare errors by ErrorType
+// Is - is the function that is used to compare errors by ErrorType, errors of child type match errors of parent type,
+// sentinel errors are compared by code and targets created by Matching function are compared by matchers
 func (e *customErr) Is(target error) bool {
-	if err, ok := target.(CustomError); ok && err.GetType() == e.errType {
+	if m, ok := target.(*matchTarget); ok {
+		return m.matcher(e)
+	}
+	if err, ok := target.(CustomError); ok && err.GetCode() != "" {
+		return err.GetCode() == e.code
+	}
+	if err, ok := target.(CustomError); ok && e.errType.IsA(err.GetType()) {
 		return true
 	}
 	return false
 }
 
+// IsClientError returns true if error type belongs to ClientError category
+func (e *customErr) IsClientError() bool {
+	return e.errType.IsA(ClientError)
+}
+
+// IsServerError returns true if error type belongs to ServerError category
+func (e *customErr) IsServerError() bool {
+	return e.errType.IsA(ServerError)
+}
+
 // IsMessageExistInStack checks if there is an error with the specified parameters in the error stack
 func (e *customErr) IsMessageExistInStack(message ErrorMessage) bool {
 	stack := make([]CustomError, 0)
@@ -50,3 +50,13 @@ func (c *customErrs) IsErrorExist(target error) bool {
 func NewMultiply() MultipleCustomErrs {
 	return &customErrs{}
 }
+
+// Unwrap returns stored errors. errors.Is and errors.As follow this method since Go 1.20 only,
+// with earlier versions use IsErrorExist or FindInStack and MatchInStack that walk stored errors themselves
+func (c *customErrs) Unwrap() []error {
+	result := make([]error, 0, len(c.errSlice))
+	for _, v := range c.errSlice {
+		result = append(result, v)
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -45,3 +46,15 @@ func Test_customErrs_isErrorExist(t *testing.T) {
 	assertions.True(errs.IsErrorExist(NotFound.NewBase("Test Not Found")))
 	assertions.False(errs.IsErrorExist(BadRequest.NewBase("Test Not Found")))
 }
+
+func Test_customErrs_Unwrap(t *testing.T) {
+	assertions := assert.New(t)
+	errs := NewMultiply()
+	notFound := NotFound.NewBase("Not Found Test")
+	errs.AddErr(notFound)
+	errs.AddErr(InternalError.NewBase("Internal Test"))
+
+	assertions.True(errors.Is(errs, notFound))
+	assertions.True(errors.Is(errs, InternalError.NewBase("Test Internal")))
+	assertions.False(errors.Is(errs, BadRequest.NewBase("Test Bad Request")))
+}
@@ -0,0 +1,235 @@
+// Package errorstest contains assertions for tests of code that returns custom errors.
+// Assertions follow testify conventions: they report failure with t.Errorf, return true on success
+// and accept optional message with arguments
+//
+//	err := repo.LoadUser(ctx, 42)
+//	errorstest.AssertChain(t, err,
+//		errorstest.Layer{Message: "load user", Type: cErrors.NotFound, Level: cErrors.DataLevel},
+//		errorstest.Layer{Message: "no rows", Type: cErrors.NotFound, Level: cErrors.DefaultLevel},
+//	)
+//
+// Failure messages contain the error chain, AssertChain prints diff of the expected and actual chains
+package errorstest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/assert"
+)
+
+// tHelper is implemented by testing.T and testing.B, it hides assertion frames from failure location
+type tHelper interface {
+	Helper()
+}
+
+// Layer is an expected single error of the chain, see AssertChain
+type Layer struct {
+	Message cErrors.ErrorMessage
+	Type    cErrors.ErrorType
+	Level   cErrors.ErrorLevel
+}
+
+// String returns layer in the same form as it is printed in chain diff
+func (l Layer) String() string {
+	return fmt.Sprintf("%s %s %q", l.Type, l.Level, l.Message)
+}
+
+// AssertType asserts that the top custom error of the chain has expected type
+func AssertType(t assert.TestingT, err error, expected cErrors.ErrorType, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	top, ok := topError(t, err, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if actual := top.GetType(); actual != expected {
+		return fail(t, err, fmt.Sprintf("Unexpected error type\nexpected: %s\nactual  : %s", expected, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// AssertLevel asserts that the top custom error of the chain has expected level
+func AssertLevel(t assert.TestingT, err error, expected cErrors.ErrorLevel, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	top, ok := topError(t, err, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if actual := top.GetLevel(); actual != expected {
+		return fail(t, err, fmt.Sprintf("Unexpected error level\nexpected: %s\nactual  : %s", expected, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// AssertSeverity asserts that the top custom error of the chain has expected severity
+func AssertSeverity(t assert.TestingT, err error, expected cErrors.ErrorSeverity, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	top, ok := topError(t, err, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if actual := top.GetSeverity(); actual != expected {
+		return fail(t, err, fmt.Sprintf("Unexpected error severity\nexpected: %s\nactual  : %s", expected, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// AssertBaggage asserts that baggage of the chain contains expected values, other keys are ignored.
+// Baggage of upper layers overrides values of lower ones
+func AssertBaggage(t assert.TestingT, err error, expected cErrors.ErrorBaggage, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if _, ok := topError(t, err, msgAndArgs...); !ok {
+		return false
+	}
+	baggage := chainBaggage(err)
+	var problems []string
+	for _, key := range sortedKeys(expected) {
+		actual, ok := baggage[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: missing, expected %#v", key, expected[key]))
+		case !assert.ObjectsAreEqual(expected[key], actual):
+			problems = append(problems, fmt.Sprintf("%s: expected %#v, actual %#v", key, expected[key], actual))
+		}
+	}
+	if len(problems) > 0 {
+		return fail(t, err, "Unexpected error baggage\n"+strings.Join(problems, "\n"), msgAndArgs...)
+	}
+	return true
+}
+
+// AssertChain asserts that custom errors of the chain from the top one to the deepest one match expected layers
+func AssertChain(t assert.TestingT, err error, expected ...Layer) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if err == nil {
+		return assert.Fail(t, "Expected error chain, got nil")
+	}
+	actual := chain(err)
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        layerLines(expected),
+		B:        layerLines(actual),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  len(expected) + len(actual),
+	})
+	return assert.Fail(t, "Unexpected error chain\n\nDiff:\n"+diff)
+}
+
+// AssertWrapped asserts that err wraps cause, errors.Is is used for comparison
+func AssertWrapped(t assert.TestingT, err, cause error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if errors.Is(err, cause) {
+		return true
+	}
+	return fail(t, err, fmt.Sprintf("Error does not wrap %q", fmt.Sprint(cause)), msgAndArgs...)
+}
+
+// AssertNotLeaked asserts that public representation of error (see errors.NewPublicError) contains
+// neither internal messages of the chain nor message of the cause. Messages equal to public messages are allowed
+func AssertNotLeaked(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if err == nil {
+		return true
+	}
+	public := cErrors.NewPublicError(err, cErrors.ExposePublic)
+	allowed := make(map[string]bool)
+	internal := []string{err.Error()}
+	for _, layer := range cErrors.FindAllInStack(err, func(cErrors.CustomError) bool { return true }) {
+		allowed[layer.GetPublicMessage().String()] = true
+		internal = append(internal, layer.GetMessage().String())
+	}
+	if customErr, ok := cErrors.FindInStack(err, func(cErrors.CustomError) bool { return true }); ok {
+		if cause := cErrors.Cause(customErr); cause != nil {
+			internal = append(internal, cause.Error())
+		}
+	}
+
+	var leaked []string
+	for _, message := range internal {
+		if message != "" && !allowed[message] && strings.Contains(public.Message, message) {
+			leaked = append(leaked, fmt.Sprintf("%q", message))
+		}
+	}
+	if len(leaked) > 0 {
+		return fail(t, err, fmt.Sprintf("Public message %q leaks internal messages: %s", public.Message, strings.Join(leaked, ", ")), msgAndArgs...)
+	}
+	return true
+}
+
+// topError returns the top custom error of the chain, failure is reported if there is no custom errors
+func topError(t assert.TestingT, err error, msgAndArgs ...interface{}) (cErrors.CustomError, bool) {
+	if err == nil {
+		return nil, assert.Fail(t, "Expected custom error, got nil", msgAndArgs...)
+	}
+	top, ok := cErrors.FindInStack(err, func(cErrors.CustomError) bool { return true })
+	if !ok {
+		return nil, assert.Fail(t, fmt.Sprintf("Expected custom error, got %T: %s", err, err), msgAndArgs...)
+	}
+	return top, true
+}
+
+// fail reports failure with the error chain
+func fail(t assert.TestingT, err error, message string, msgAndArgs ...interface{}) bool {
+	return assert.Fail(t, message+"\n\nChain:\n"+strings.Join(layerLines(chain(err)), ""), msgAndArgs...)
+}
+
+// chain returns layers of custom errors of err
+func chain(err error) []Layer {
+	var result []Layer
+	for _, layer := range cErrors.FindAllInStack(err, func(cErrors.CustomError) bool { return true }) {
+		result = append(result, Layer{Message: layer.GetMessage(), Type: layer.GetType(), Level: layer.GetLevel()})
+	}
+	return result
+}
+
+// layerLines returns numbered lines of layers for diff
+func layerLines(layers []Layer) []string {
+	lines := make([]string, 0, len(layers))
+	for k, layer := range layers {
+		lines = append(lines, fmt.Sprintf("#%d %s\n", k, layer))
+	}
+	return lines
+}
+
+// chainBaggage returns baggage of the whole error chain, upper layers override values of lower ones
+func chainBaggage(err error) cErrors.ErrorBaggage {
+	layers := cErrors.FindAllInStack(err, func(cErrors.CustomError) bool { return true })
+	result := make(cErrors.ErrorBaggage)
+	for k := len(layers) - 1; k >= 0; k-- {
+		for key, value := range layers[k].GetBaggage() {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func sortedKeys(baggage cErrors.ErrorBaggage) []string {
+	keys := make([]string, 0, len(baggage))
+	for key := range baggage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package errorstest
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder collects failures of assertions
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func newChain() cErrors.CustomError {
+	base := cErrors.NotFound.NewBase("no rows").SetBaggage(cErrors.ErrorBaggage{"table": "users", "userID": 7})
+	return cErrors.WrapWith(base, "load user", cErrors.WithLevel(cErrors.DataLevel), cErrors.WithSeverity(cErrors.Warning),
+		cErrors.WithBaggage(cErrors.ErrorBaggage{"userID": 42}))
+}
+
+func TestAssertions_Success(t *testing.T) {
+	err := newChain()
+	AssertType(t, err, cErrors.NotFound)
+	AssertLevel(t, err, cErrors.DataLevel)
+	AssertSeverity(t, err, cErrors.Warning)
+	AssertBaggage(t, err, cErrors.ErrorBaggage{"userID": 42, "table": "users"})
+	AssertChain(t, fmt.Errorf("handler: %w", err),
+		Layer{Message: "load user", Type: cErrors.NotFound, Level: cErrors.DataLevel},
+		Layer{Message: "no rows", Type: cErrors.NotFound, Level: cErrors.DefaultLevel},
+	)
+	AssertWrapped(t, cErrors.Wrap(io.EOF, "read body"), io.EOF)
+	AssertNotLeaked(t, cErrors.WrapWith(io.EOF, "read body", cErrors.WithPublicMessage("request is cancelled")))
+}
+
+func TestAssertions_Failure(t *testing.T) {
+	assertions := assert.New(t)
+	err := newChain()
+	for _, testCase := range []struct {
+		name     string
+		assert   func(t assert.TestingT) bool
+		contains []string
+	}{
+		{
+			name:     "type",
+			assert:   func(t assert.TestingT) bool { return AssertType(t, err, cErrors.Conflict) },
+			contains: []string{"expected: Conflict", "actual  : NotFound", `#1 NotFound DefaultLevel "no rows"`},
+		},
+		{
+			name:     "level",
+			assert:   func(t assert.TestingT) bool { return AssertLevel(t, err, cErrors.UseCaseLevel, "load %d", 42) },
+			contains: []string{"expected: UseCaseLevel", "actual  : DataLevel", "load 42"},
+		},
+		{
+			name:     "severity",
+			assert:   func(t assert.TestingT) bool { return AssertSeverity(t, err, cErrors.Critical) },
+			contains: []string{"expected: Critical", "actual  : Warning"},
+		},
+		{
+			name:     "not custom error",
+			assert:   func(t assert.TestingT) bool { return AssertType(t, io.EOF, cErrors.NotFound) },
+			contains: []string{"Expected custom error, got *errors.errorString: EOF"},
+		},
+		{
+			name: "baggage",
+			assert: func(t assert.TestingT) bool {
+				return AssertBaggage(t, err, cErrors.ErrorBaggage{"userID": 7, "tenant": "acme"})
+			},
+			contains: []string{`tenant: missing, expected "acme"`, "userID: expected 7, actual 42"},
+		},
+		{
+			name: "chain",
+			assert: func(t assert.TestingT) bool {
+				return AssertChain(t, err,
+					Layer{Message: "load user", Type: cErrors.NotFound, Level: cErrors.UseCaseLevel},
+					Layer{Message: "no rows", Type: cErrors.NotFound, Level: cErrors.DefaultLevel},
+				)
+			},
+			contains: []string{
+				"--- Expected",
+				`-#0 NotFound UseCaseLevel "load user"`,
+				`+#0 NotFound DataLevel "load user"`,
+				` #1 NotFound DefaultLevel "no rows"`,
+			},
+		},
+		{
+			name:     "wrapped",
+			assert:   func(t assert.TestingT) bool { return AssertWrapped(t, err, io.EOF) },
+			contains: []string{`Error does not wrap "EOF"`},
+		},
+		{
+			name: "leaked",
+			assert: func(t assert.TestingT) bool {
+				return AssertNotLeaked(t, cErrors.WrapWith(io.EOF, "read body", cErrors.WithPublicMessage("read body failed")))
+			},
+			contains: []string{`leaks internal messages: "read body"`},
+		},
+	} {
+		r := &recorder{}
+		assertions.False(testCase.assert(r), testCase.name)
+		if assertions.Len(r.messages, 1, testCase.name) {
+			for _, v := range testCase.contains {
+				assertions.Contains(r.messages[0], v, testCase.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package errorstest
+
+import (
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// EnableFailpoint enables failpoint (see errors.Inject) for the test. When the test finishes failpoint is disabled
+// or the previous failpoint with the same name is enabled again
+//
+//	errorstest.EnableFailpoint(t, "repo.user.load", cErrors.Failpoint{Type: cErrors.Unavailable, Level: cErrors.DataLevel})
+//	_, err := service.GetUser(ctx, 42)
+//	errorstest.AssertType(t, err, cErrors.Unavailable)
+func EnableFailpoint(t testing.TB, name string, failpoint cErrors.Failpoint) {
+	t.Helper()
+	previous, enabled := findFailpoint(name)
+	cErrors.EnableFailpoint(name, failpoint)
+	t.Cleanup(func() {
+		if enabled {
+			cErrors.EnableFailpoint(name, previous)
+			return
+		}
+		cErrors.DisableFailpoint(name)
+	})
+}
+
+// findFailpoint returns enabled failpoint with the name
+func findFailpoint(name string) (cErrors.Failpoint, bool) {
+	for _, v := range cErrors.Failpoints() {
+		if v.Name == name {
+			return v.Failpoint, true
+		}
+	}
+	return cErrors.Failpoint{}, false
+}
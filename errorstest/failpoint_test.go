@@ -0,0 +1,33 @@
+package errorstest
+
+import (
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableFailpoint(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		EnableFailpoint(t, "repo.user.load", cErrors.Failpoint{Type: cErrors.Unavailable, Level: cErrors.DataLevel})
+		err := cErrors.Inject("repo.user.load")
+		AssertType(t, err, cErrors.Unavailable)
+		AssertLevel(t, err, cErrors.DataLevel)
+		AssertBaggage(t, err, cErrors.ErrorBaggage{"failpoint": "repo.user.load"})
+	})
+	assert.Nil(t, cErrors.Inject("repo.user.load"), "Check that failpoint is disabled after the test")
+
+	t.Run("outer", func(t *testing.T) {
+		EnableFailpoint(t, "repo.user.load", cErrors.Failpoint{Type: cErrors.Timeout, Count: 5})
+		t.Run("inner", func(t *testing.T) {
+			EnableFailpoint(t, "repo.user.load", cErrors.Failpoint{Type: cErrors.Unavailable})
+			AssertType(t, cErrors.Inject("repo.user.load"), cErrors.Unavailable)
+		})
+		AssertType(t, cErrors.Inject("repo.user.load"), cErrors.Timeout, "Check that previous failpoint is restored")
+		states := cErrors.Failpoints()
+		if assert.Len(t, states, 1) {
+			assert.Equal(t, 5, states[0].Count)
+		}
+	})
+	assert.Nil(t, cErrors.Inject("repo.user.load"))
+}
@@ -0,0 +1,102 @@
+package errorstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/assert"
+)
+
+// Golden files of snapshots are rewritten instead of comparing if tests run with -errorstest.update flag
+// or UpdateEnv environment variable is set to true, e.g. ERRORSTEST_UPDATE=1 go test ./...
+const (
+	UpdateFlag = "errorstest.update"
+	UpdateEnv  = "ERRORSTEST_UPDATE"
+)
+
+// flag is registered only if it is not registered yet, e.g. by the other copy of the package
+func init() {
+	if flag.Lookup(UpdateFlag) == nil {
+		flag.Bool(UpdateFlag, false, "update golden files of error snapshots")
+	}
+}
+
+// updating returns true if golden files should be rewritten
+func updating() bool {
+	if update, err := strconv.ParseBool(os.Getenv(UpdateEnv)); err == nil && update {
+		return true
+	}
+	f := flag.Lookup(UpdateFlag)
+	if f == nil {
+		return false
+	}
+	update, _ := strconv.ParseBool(f.Value.String())
+	return update
+}
+
+// AssertSnapshot asserts that error rendered in deterministic mode (see errors.Render) equals content of
+// golden file testdata/<name>. Golden files are created or rewritten when tests run with -errorstest.update flag
+//
+//	errorstest.AssertSnapshot(t, err, "load_user.golden")
+func AssertSnapshot(t assert.TestingT, err error, name string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return assertGolden(t, []byte(cErrors.Render(err, cErrors.RenderDeterministic)), name, msgAndArgs...)
+}
+
+// AssertRecordSnapshot asserts that JSON representation of error (see errors.ErrorRecord) normalized
+// for deterministic mode equals content of golden file testdata/<name>, see AssertSnapshot
+func AssertRecordSnapshot(t assert.TestingT, err error, name string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	top, ok := topError(t, err, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(cErrors.NewErrorRecord(top).Normalize(cErrors.RenderDeterministic)); err != nil {
+		return assert.Fail(t, "Could not marshal error record: "+err.Error(), msgAndArgs...)
+	}
+	return assertGolden(t, data.Bytes(), name, msgAndArgs...)
+}
+
+// assertGolden compares actual content with golden file or rewrites it in update mode
+func assertGolden(t assert.TestingT, actual []byte, name string, msgAndArgs ...interface{}) bool {
+	path := filepath.Join("testdata", name)
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return assert.Fail(t, "Could not create golden file directory: "+err.Error(), msgAndArgs...)
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			return assert.Fail(t, "Could not update golden file: "+err.Error(), msgAndArgs...)
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		return assert.Fail(t, "Could not read golden file, run tests with -"+UpdateFlag+" flag to create it: "+err.Error(), msgAndArgs...)
+	}
+	if bytes.Equal(expected, actual) {
+		return true
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(actual)),
+		FromFile: path,
+		ToFile:   "Actual",
+		Context:  3,
+	})
+	return assert.Fail(t, "Error does not match snapshot, run tests with -"+UpdateFlag+" flag to update it\n\nDiff:\n"+diff, msgAndArgs...)
+}
@@ -0,0 +1,71 @@
+package errorstest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertSnapshot(t *testing.T) {
+	assertions := assert.New(t)
+	err := newChain()
+	AssertSnapshot(t, err, "chain.golden")
+	AssertRecordSnapshot(t, err, "chain.json")
+	if updating() {
+		return
+	}
+
+	r := &recorder{}
+	assertions.False(AssertSnapshot(r, cErrors.Wrap(err, "handle request"), "chain.golden"))
+	if assertions.Len(r.messages, 1) {
+		assertions.Contains(r.messages[0], "+#0 NotFound DataLevel Warning: handle request")
+		assertions.Contains(r.messages[0], "-load user: no rows")
+	}
+
+	r = &recorder{}
+	assertions.False(AssertSnapshot(r, err, "missing.golden"))
+	assertions.Len(r.messages, 1)
+}
+
+func TestAssertSnapshot_Update(t *testing.T) {
+	assertions := assert.New(t)
+	wd, _ := os.Getwd()
+	dir := t.TempDir()
+	assertions.NoError(os.Chdir(dir))
+	defer func() { _ = os.Chdir(wd) }()
+
+	assertions.NoError(flag.Set(UpdateFlag, "true"))
+	defer func() { _ = flag.Set(UpdateFlag, "false") }()
+	assertions.True(AssertSnapshot(t, newChain(), "nested/chain.golden"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "testdata", "nested", "chain.golden"))
+	assertions.NoError(err)
+	assertions.Contains(string(data), "errorstest/assert_test.go:<line>")
+}
+
+func TestUpdating(t *testing.T) {
+	assertions := assert.New(t)
+	update := flag.Lookup(UpdateFlag).Value.String()
+	defer func() { _ = flag.Set(UpdateFlag, update) }()
+	env, envSet := os.LookupEnv(UpdateEnv)
+	defer func() {
+		if envSet {
+			_ = os.Setenv(UpdateEnv, env)
+		} else {
+			_ = os.Unsetenv(UpdateEnv)
+		}
+	}()
+	assertions.NoError(flag.Set(UpdateFlag, "false"))
+	assertions.NoError(os.Unsetenv(UpdateEnv))
+	assertions.False(updating())
+
+	assertions.NoError(os.Setenv(UpdateEnv, "1"))
+	assertions.True(updating(), "Check environment variable")
+
+	assertions.NoError(os.Setenv(UpdateEnv, "no"))
+	assertions.False(updating())
+}
@@ -1,6 +1,9 @@
 package errors
 
 import (
+	"fmt"
+	"sync"
+
 	errs "github.com/pkg/errors"
 )
 
@@ -61,3 +64,87 @@ func (i ErrorType) WrapF(err error, format string, args ...interface{}) CustomEr
 	}
 	return newCustomErr(i, make(ErrorBaggage), DefaultLevel, DefaultSeverity, wrappedErr)
 }
+
+// typeNames contain names of own error types, see SetTypeName
+var typeNames = struct {
+	sync.RWMutex
+	names map[ErrorType]string
+}{names: make(map[ErrorType]string)}
+
+// SetTypeName sets name of own error type, it is used by String and ParseErrorType.
+// Names of built-in types could not be changed
+func SetTypeName(errType ErrorType, name string) {
+	typeNames.Lock()
+	defer typeNames.Unlock()
+	typeNames.names[errType] = name
+}
+
+// typeName returns name of own error type
+func typeName(errType ErrorType) (string, bool) {
+	typeNames.RLock()
+	defer typeNames.RUnlock()
+	name, ok := typeNames.names[errType]
+	return name, ok
+}
+
+// typeParents describes hierarchy of error types, it maps error type to its parent category
+var typeParents = struct {
+	sync.RWMutex
+	parents map[ErrorType]ErrorType
+}{parents: map[ErrorType]ErrorType{
+	NotFound:         ClientError,
+	InvalidArguments: ClientError,
+	BadRequest:       ClientError,
+	AccessDenied:     ClientError,
+	Unauthorized:     ClientError,
+	InternalError:    ServerError,
+
+	Conflict:           ClientError,
+	AlreadyExists:      Conflict,
+	PreconditionFailed: ClientError,
+	RateLimited:        ClientError,
+	Canceled:           ClientError,
+
+	Timeout:        ServerError,
+	Unavailable:    ServerError,
+	NotImplemented: ServerError,
+	DataLoss:       ServerError,
+}}
+
+// SetTypeParent sets parent category of error type, it could be used for built-in and own error types:
+//
+//	const PaymentRequired = errors.ErrorType(100)
+//	errors.SetTypeParent(PaymentRequired, errors.ClientError)
+//
+// Error of child type is matched by errors.Is with errors of parent type, HasType matcher and HTTP mapping.
+// SetTypeParent panics if the relationship creates a cycle
+func SetTypeParent(child, parent ErrorType) {
+	typeParents.Lock()
+	defer typeParents.Unlock()
+	for current, ok := parent, true; ok; current, ok = typeParents.parents[current] {
+		if current == child {
+			panic(fmt.Sprintf("errors: type %s can not be a parent of %s as it creates a cycle", parent, child))
+		}
+	}
+	typeParents.parents[child] = parent
+}
+
+// Parent returns parent category of error type
+func (i ErrorType) Parent() (ErrorType, bool) {
+	typeParents.RLock()
+	defer typeParents.RUnlock()
+	parent, ok := typeParents.parents[i]
+	return parent, ok
+}
+
+// IsA returns true if error type is equal to the provided type or it is a descendant of the provided type
+func (i ErrorType) IsA(ancestor ErrorType) bool {
+	typeParents.RLock()
+	defer typeParents.RUnlock()
+	for current, ok := i, true; ok; current, ok = typeParents.parents[current] {
+		if current == ancestor {
+			return true
+		}
+	}
+	return false
+}
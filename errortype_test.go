@@ -0,0 +1,68 @@
+package errors
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorType_IsA(t *testing.T) {
+	assertions := assert.New(t)
+
+	for _, v := range []ErrorType{NotFound, InvalidArguments, BadRequest, AccessDenied, Unauthorized} {
+		assertions.True(v.IsA(ClientError), "Check %s category", v)
+		assertions.False(v.IsA(ServerError), "Check %s category", v)
+	}
+	assertions.True(InternalError.IsA(ServerError))
+	assertions.True(NotFound.IsA(NotFound))
+	assertions.False(ClientError.IsA(NotFound), "Check that parent is not a child")
+	assertions.False(DefaultType.IsA(ClientError))
+
+	parent, ok := NotFound.Parent()
+	assertions.True(ok)
+	assertions.Equal(ClientError, parent)
+	_, ok = ClientError.Parent()
+	assertions.False(ok)
+}
+
+// restoreTypeParents restores hierarchy of error types after the test
+func restoreTypeParents(t *testing.T) {
+	typeParents.RLock()
+	parents := make(map[ErrorType]ErrorType, len(typeParents.parents))
+	for k, v := range typeParents.parents {
+		parents[k] = v
+	}
+	typeParents.RUnlock()
+	t.Cleanup(func() {
+		typeParents.Lock()
+		typeParents.parents = parents
+		typeParents.Unlock()
+	})
+}
+
+func TestSetTypeParent(t *testing.T) {
+	assertions := assert.New(t)
+	restoreTypeParents(t)
+	userNotFound := ErrorType(100)
+	deletedUser := ErrorType(101)
+	SetTypeParent(userNotFound, NotFound)
+	SetTypeParent(deletedUser, userNotFound)
+
+	assertions.True(deletedUser.IsA(userNotFound))
+	assertions.True(deletedUser.IsA(NotFound))
+	assertions.True(deletedUser.IsA(ClientError), "Check transitive parents")
+
+	assertions.Panics(func() { SetTypeParent(NotFound, deletedUser) }, "Check cycle detection")
+	assertions.Panics(func() { SetTypeParent(NotFound, NotFound) }, "Check cycle detection")
+
+	err := Wrap(deletedUser.NewBase("deleted user"), "load user")
+	assertions.True(errs.Is(err, NotFound.NewBase("")), "Check comparison with parent type")
+	assertions.True(errs.Is(err, ClientError.NewBase("")), "Check comparison with category")
+	assertions.False(errs.Is(NotFound.NewBase(""), deletedUser.NewBase("")), "Check that parent does not match child")
+	assertions.True(MatchInStack(err, HasType(ClientError)))
+	assertions.False(MatchInStack(err, HasExactType(NotFound)))
+	assertions.True(err.IsClientError())
+	assertions.False(err.IsServerError())
+	assertions.True(InternalError.NewBase("internal").IsServerError())
+}
@@ -0,0 +1,190 @@
+package errstore
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var referenceTime = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// testStore open store with clock that moves forward for a minute on every call
+func testStore(t *testing.T, opts Options) *Store {
+	current := referenceTime
+	opts.Now = func() time.Time {
+		current = current.Add(time.Minute)
+		return current
+	}
+	store, err := Open(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestStore_Record(t *testing.T) {
+	assertions := assert.New(t)
+	dir := t.TempDir()
+	store := testStore(t, Options{Dir: dir})
+
+	for k := 0; k < 3; k++ {
+		assertions.NoError(store.Record(cErrors.NewBase(cErrors.ErrorMessage(fmt.Sprintf("%d error", k)))))
+	}
+	assertions.NoError(store.Record(nil))
+
+	entries, err := store.Query(Filter{})
+	assertions.NoError(err)
+	if assertions.Len(entries, 3) {
+		for k, v := range []string{"2 error", "1 error", "0 error"} {
+			assertions.Equal(v, entries[k].Error, "Check that the newest errors go first (%d)", k)
+		}
+		assertions.Equal(referenceTime.Add(3*time.Minute), entries[0].Time.UTC())
+	}
+
+	assertions.NoError(store.Close())
+	reopened := testStore(t, Options{Dir: dir})
+	assertions.NoError(reopened.Record(cErrors.NewBase("after reopen")))
+	entries, err = Query(dir, Filter{Limit: 2})
+	assertions.NoError(err)
+	if assertions.Len(entries, 2, "Check limit") {
+		assertions.Equal("after reopen", entries[0].Error, "Check that errors are appended to existing file")
+		assertions.Equal("2 error", entries[1].Error)
+	}
+}
+
+func TestStore_Rotate(t *testing.T) {
+	assertions := assert.New(t)
+	dir := t.TempDir()
+	store := testStore(t, Options{Dir: dir, MaxFileSize: 1, MaxFiles: 3})
+
+	for k := 0; k < 5; k++ {
+		assertions.NoError(store.Record(cErrors.NewBase(cErrors.ErrorMessage(fmt.Sprintf("%d error", k)))))
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	assertions.Len(files, 3, "Check that the oldest files are removed")
+	assertions.FileExists(filepath.Join(dir, "errors.2.jsonl"))
+
+	entries, err := store.Query(Filter{})
+	assertions.NoError(err)
+	if assertions.Len(entries, 3) {
+		for k, v := range []string{"4 error", "3 error", "2 error"} {
+			assertions.Equal(v, entries[k].Error, "Check order across files (%d)", k)
+		}
+	}
+
+	assertions.NoError(os.WriteFile(filepath.Join(dir, "errors.9.jsonl"), []byte("{broken\n{\"time\":\"2020-01-01T00:00:00Z\"}\n"), 0o644))
+	entries, err = store.Query(Filter{})
+	assertions.NoError(err)
+	assertions.Len(entries, 3, "Check that malformed lines are skipped")
+}
+
+func TestFilter_Match(t *testing.T) {
+	assertions := assert.New(t)
+	store := testStore(t, Options{Dir: t.TempDir()})
+
+	notFound := cErrors.NotFound.NewBase("no rows").SetBaggage(cErrors.ErrorBaggage{"table": "users"})
+	for _, useCase := range []bool{true, false, true} {
+		if useCase {
+			assertions.NoError(store.Record(cErrors.Wrap(notFound, "load user").SetLevel(cErrors.UseCaseLevel)))
+			continue
+		}
+		assertions.NoError(store.Record(cErrors.InternalError.NewBase("timeout").SetSeverity(cErrors.Critical)))
+	}
+
+	entries, _ := store.Query(Filter{})
+	fingerprint := entries[0].Fingerprint
+
+	for _, testCase := range []struct {
+		name   string
+		filter Filter
+		count  int
+	}{
+		{name: "type", filter: Filter{Types: []cErrors.ErrorType{cErrors.NotFound}}, count: 2},
+		{name: "level", filter: Filter{Levels: []cErrors.ErrorLevel{cErrors.UseCaseLevel}}, count: 2},
+		{name: "severity", filter: Filter{Severities: []cErrors.ErrorSeverity{cErrors.Critical}}, count: 1},
+		{name: "fingerprint", filter: Filter{Fingerprint: fingerprint}, count: 2},
+		{name: "baggage of deep layer", filter: Filter{Baggage: map[string]string{"table": "users"}}, count: 2},
+		{name: "baggage mismatch", filter: Filter{Baggage: map[string]string{"table": "orders"}}, count: 0},
+		{name: "since", filter: Filter{Since: referenceTime.Add(2 * time.Minute)}, count: 2},
+		{name: "until", filter: Filter{Until: referenceTime.Add(2 * time.Minute)}, count: 2},
+	} {
+		entries, err := store.Query(testCase.filter)
+		assertions.NoError(err)
+		assertions.Len(entries, testCase.count, testCase.name)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	assertions := assert.New(t)
+	query := url.Values{
+		"type":     {"NotFound", "InternalError"},
+		"level":    {"DataLevel"},
+		"severity": {"Critical"},
+		"baggage":  {"userID=42", "tenant=acme"},
+		"since":    {"1h"},
+		"limit":    {"10"},
+	}
+	filter, err := ParseFilter(query, referenceTime)
+	assertions.NoError(err)
+	assertions.Equal([]cErrors.ErrorType{cErrors.NotFound, cErrors.InternalError}, filter.Types)
+	assertions.Equal(map[string]string{"userID": "42", "tenant": "acme"}, filter.Baggage)
+	assertions.Equal(referenceTime.Add(-time.Hour), filter.Since)
+	assertions.Equal(10, filter.Limit)
+
+	_, err = ParseFilter(url.Values{"baggage": {"userID"}}, referenceTime)
+	assertions.Error(err)
+}
+
+func TestParseFilter_UnknownNames(t *testing.T) {
+	assertions := assert.New(t)
+	filter, err := ParseFilter(url.Values{"type": {"NotFound", "UserNotFound"}, "severity": {"Urgent"}}, referenceTime)
+	assertions.NoError(err)
+	assertions.Equal([]cErrors.ErrorType{cErrors.NotFound}, filter.Types)
+	assertions.Equal([]string{"UserNotFound"}, filter.TypeNames, "Check that catalog type of other service is kept as is")
+	assertions.Equal([]string{"Urgent"}, filter.SeverityNames)
+
+	entry := func(errType, severity string) Entry {
+		return Entry{ErrorRecord: cErrors.ErrorRecord{Stack: []cErrors.LayerRecord{{Type: errType, Severity: severity}}}}
+	}
+	filter.SeverityNames = nil
+	assertions.True(filter.Match(entry("UserNotFound", "Warning")))
+	assertions.True(filter.Match(entry("usernotfound", "Warning")), "Check that names are compared case-insensitively")
+	assertions.True(filter.Match(entry("NotFound", "Warning")))
+	assertions.False(filter.Match(entry("OrderNotFound", "Warning")))
+}
+
+func TestGroupBy(t *testing.T) {
+	assertions := assert.New(t)
+	store := testStore(t, Options{Dir: t.TempDir()})
+	for k := 0; k < 3; k++ {
+		assertions.NoError(store.Record(cErrors.NotFound.NewBase("no rows").SetBaggage(cErrors.ErrorBaggage{"userID": k % 2})))
+	}
+	assertions.NoError(store.Record(cErrors.InternalError.NewBase("timeout")))
+
+	entries, _ := store.Query(Filter{})
+	groups, err := GroupBy(entries, ByType)
+	assertions.NoError(err)
+	if assertions.Len(groups, 2) {
+		assertions.Equal("NotFound", groups[0].Key)
+		assertions.Equal(3, groups[0].Count)
+		assertions.Equal(referenceTime.Add(time.Minute), groups[0].First.UTC())
+		assertions.Equal(referenceTime.Add(3*time.Minute), groups[0].Last.UTC())
+		assertions.Equal("no rows", groups[0].Example)
+	}
+
+	groups, err = GroupBy(entries, ByBaggage+"userID")
+	assertions.NoError(err)
+	if assertions.Len(groups, 2, "Check that entries without baggage are skipped") {
+		assertions.Equal(Group{Key: "0", Count: 2, First: groups[0].First, Last: groups[0].Last, Example: "no rows"}, groups[0])
+	}
+
+	_, err = GroupBy(entries, "unknown")
+	assertions.Error(err)
+}
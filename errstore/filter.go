@@ -0,0 +1,169 @@
+package errstore
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Filter describes which entries should be returned from the store,
+// empty fields are not taken into account
+type Filter struct {
+	// Types, Levels and Severities are compared with the top error of the stack
+	Types      []cErrors.ErrorType
+	Levels     []cErrors.ErrorLevel
+	Severities []cErrors.ErrorSeverity
+	// TypeNames, LevelNames and SeverityNames are names that are not known to this process (e.g. types of
+	// the error catalog of the recording service), they are compared with names of the top error case-insensitively
+	TypeNames     []string
+	LevelNames    []string
+	SeverityNames []string
+	Fingerprint   string
+	// Baggage values are compared with string representation of baggage of any error in the stack
+	Baggage map[string]string
+	// Since and Until bound the time window of error recording
+	Since time.Time
+	Until time.Time
+	// Limit is a max count of returned entries
+	Limit int
+}
+
+// Match checks that entry satisfies all filter conditions
+func (f Filter) Match(entry Entry) bool {
+	if len(entry.Stack) == 0 {
+		return false
+	}
+	top := entry.Stack[0]
+	if !matchName(len(f.Types), func(k int) string { return f.Types[k].String() }, f.TypeNames, top.Type) {
+		return false
+	}
+	if !matchName(len(f.Levels), func(k int) string { return f.Levels[k].String() }, f.LevelNames, top.Level) {
+		return false
+	}
+	if !matchName(len(f.Severities), func(k int) string { return f.Severities[k].String() }, f.SeverityNames, top.Severity) {
+		return false
+	}
+	if f.Fingerprint != "" && f.Fingerprint != entry.Fingerprint {
+		return false
+	}
+	for k, v := range f.Baggage {
+		if value, ok := BaggageValue(entry, k); !ok || value != v {
+			return false
+		}
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && entry.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// BaggageValue returns string representation of baggage value by key, the top error of the stack has priority
+func BaggageValue(entry Entry, key string) (string, bool) {
+	for _, layer := range entry.Stack {
+		if v, ok := layer.Baggage[key]; ok {
+			return fmt.Sprint(v), true
+		}
+	}
+	return "", false
+}
+
+// ParseFilter builds Filter from the url query, supported parameters are:
+//
+//	type, level, severity - names or codes, could be repeated, unknown names are kept as is
+//	fingerprint           - error fingerprint
+//	baggage               - key=value pair, could be repeated
+//	since, until          - RFC3339 time or duration before now (e.g. 15m)
+//	limit                 - max count of errors
+func ParseFilter(query url.Values, now time.Time) (Filter, error) {
+	var filter Filter
+	for _, v := range query["type"] {
+		errType, err := cErrors.ParseErrorType(v)
+		if err != nil {
+			filter.TypeNames = append(filter.TypeNames, v)
+			continue
+		}
+		filter.Types = append(filter.Types, errType)
+	}
+	for _, v := range query["level"] {
+		level, err := cErrors.ParseErrorLevel(v)
+		if err != nil {
+			filter.LevelNames = append(filter.LevelNames, v)
+			continue
+		}
+		filter.Levels = append(filter.Levels, level)
+	}
+	for _, v := range query["severity"] {
+		severity, err := cErrors.ParseErrorSeverity(v)
+		if err != nil {
+			filter.SeverityNames = append(filter.SeverityNames, v)
+			continue
+		}
+		filter.Severities = append(filter.Severities, severity)
+	}
+	filter.Fingerprint = query.Get("fingerprint")
+	for _, v := range query["baggage"] {
+		pair := strings.SplitN(v, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return filter, fmt.Errorf("invalid baggage %q, key=value expected", v)
+		}
+		if filter.Baggage == nil {
+			filter.Baggage = make(map[string]string)
+		}
+		filter.Baggage[pair[0]] = pair[1]
+	}
+
+	var err error
+	if filter.Since, err = parseTime(query.Get("since"), now); err != nil {
+		return filter, fmt.Errorf("invalid since: %w", err)
+	}
+	if filter.Until, err = parseTime(query.Get("until"), now); err != nil {
+		return filter, fmt.Errorf("invalid until: %w", err)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return filter, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// parseTime parses RFC3339 time or duration that is subtracted from now
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// matchName checks that one of count names or raw names equals target, filter without names matches any target
+func matchName(count int, name func(k int) string, raw []string, target string) bool {
+	if count == 0 && len(raw) == 0 {
+		return true
+	}
+	for _, v := range raw {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return containsName(count, name, target)
+}
+
+// containsName checks that one of count names equals target
+func containsName(count int, name func(k int) string, target string) bool {
+	for k := 0; k < count; k++ {
+		if name(k) == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+// Package errstore keeps reported custom errors in JSONL files, so small services without a log stack
+// could answer what failed and how often.
+//
+// Every line of the file is an errors.ErrorRecord with time of recording. Current file is rotated when it reaches
+// the size limit, the oldest files are removed when count of files reaches the limit
+//
+//	store, err := errstore.Open(errstore.Options{Dir: "/var/lib/users/errors"})
+//	//....
+//	store.Record(err)
+//
+//	entries, err := store.Query(errstore.Filter{Types: []cErrors.ErrorType{cErrors.NotFound}, Since: time.Now().Add(-time.Hour)})
+package errstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Default limits of Store
+const (
+	DefaultMaxFileSize = 10 << 20
+	DefaultMaxFiles    = 5
+)
+
+// fileName is a name of current file, rotated files have number suffix, e.g. errors.1.jsonl
+const fileName = "errors"
+
+// Entry is a single stored error
+type Entry struct {
+	// Time of error recording
+	Time time.Time `json:"time"`
+	cErrors.ErrorRecord
+}
+
+// Options of Store
+type Options struct {
+	// Dir is a directory of files, it is created if not exist
+	Dir string
+	// MaxFileSize is a size of file in bytes that causes rotation, DefaultMaxFileSize is used if not set
+	MaxFileSize int64
+	// MaxFiles is a max count of files including the current one, DefaultMaxFiles is used if not set
+	MaxFiles int
+	// Now returns current time, time.Now is used if not set
+	Now func() time.Time
+}
+
+// Store appends errors to JSONL files. It is safe for concurrent use
+type Store struct {
+	mu   sync.Mutex
+	opts Options
+	file *os.File
+	size int64
+}
+
+// Open opens store in directory, errors are appended to existing current file
+func Open(opts Options) (*Store, error) {
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+	if opts.MaxFiles <= 0 {
+		opts.MaxFiles = DefaultMaxFiles
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create store directory: %w", err)
+	}
+	s := &Store{opts: opts}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Record appends error to the current file, nil errors are ignored
+func (s *Store) Record(err cErrors.CustomError) error {
+	if err == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, marshalErr := json.Marshal(Entry{Time: s.opts.Now(), ErrorRecord: cErrors.NewErrorRecord(err)})
+	if marshalErr != nil {
+		return fmt.Errorf("marshal error record: %w", marshalErr)
+	}
+	line = append(line, '\n')
+	if s.size > 0 && s.size+int64(len(line)) > s.opts.MaxFileSize {
+		if rotateErr := s.rotate(); rotateErr != nil {
+			return rotateErr
+		}
+	}
+	n, writeErr := s.file.Write(line)
+	s.size += int64(n)
+	if writeErr != nil {
+		return fmt.Errorf("write error record: %w", writeErr)
+	}
+	return nil
+}
+
+// Query returns stored errors that match filter, the newest errors go first
+func (s *Store) Query(filter Filter) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Query(s.opts.Dir, filter)
+}
+
+// Close closes the current file
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// open opens the current file for appending
+func (s *Store) open() error {
+	file, err := os.OpenFile(filePath(s.opts.Dir, 0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open store file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat store file: %w", err)
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts numbers of rotated files, removes the oldest one and opens new current file
+func (s *Store) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("close store file: %w", err)
+	}
+	if err := os.Remove(filePath(s.opts.Dir, s.opts.MaxFiles-1)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove the oldest store file: %w", err)
+	}
+	for k := s.opts.MaxFiles - 2; k >= 0; k-- {
+		if err := os.Rename(filePath(s.opts.Dir, k), filePath(s.opts.Dir, k+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rotate store file: %w", err)
+		}
+	}
+	return s.open()
+}
+
+// Query reads errors that match filter from store directory, the newest errors go first. Malformed lines are skipped
+func Query(dir string, filter Filter) ([]Entry, error) {
+	numbers, err := fileNumbers(dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []Entry
+	for _, number := range numbers {
+		entries, err := readFile(filePath(dir, number))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !filter.Match(entries[i]) {
+				continue
+			}
+			result = append(result, entries[i])
+			if filter.Limit > 0 && len(result) == filter.Limit {
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+// fileNumbers returns numbers of store files from the current one to the oldest one
+func fileNumbers(dir string) ([]int, error) {
+	names, err := filepath.Glob(filepath.Join(dir, fileName+"*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]int, 0, len(names))
+	for _, name := range names {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), fileName), ".jsonl")
+		if suffix == "" {
+			numbers = append(numbers, 0)
+			continue
+		}
+		if number, err := strconv.Atoi(strings.TrimPrefix(suffix, ".")); err == nil && strings.HasPrefix(suffix, ".") {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// readFile returns entries of file in order of recording
+func readFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && len(entry.Stack) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// filePath returns path of the current file for zero number and paths of rotated files otherwise
+func filePath(dir string, number int) string {
+	if number == 0 {
+		return filepath.Join(dir, fileName+".jsonl")
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s.%d.jsonl", fileName, number))
+}
@@ -0,0 +1,88 @@
+package errstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Group keys supported by GroupBy, baggage values are grouped by "baggage:<key>"
+const (
+	ByFingerprint = "fingerprint"
+	ByType        = "type"
+	ByLevel       = "level"
+	BySeverity    = "severity"
+	ByCode        = "code"
+	ByBaggage     = "baggage:"
+)
+
+// Group is a summary of entries that have the same value of group key
+type Group struct {
+	// Key is a value of group key
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+	// First and Last are times of the oldest and the newest entries
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+	// Example is an error string of the newest entry
+	Example string `json:"example"`
+}
+
+// GroupBy summarizes entries by key, groups are sorted by count, the most frequent group goes first.
+// Entries without baggage key are skipped
+func GroupBy(entries []Entry, by string) ([]Group, error) {
+	key, err := groupKey(by)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int)
+	var groups []Group
+	for _, entry := range entries {
+		value, ok := key(entry)
+		if !ok {
+			continue
+		}
+		k, exist := index[value]
+		if !exist {
+			k = len(groups)
+			index[value] = k
+			groups = append(groups, Group{Key: value, First: entry.Time, Last: entry.Time, Example: entry.Error})
+		}
+		group := &groups[k]
+		group.Count++
+		if entry.Time.Before(group.First) {
+			group.First = entry.Time
+		}
+		if entry.Time.After(group.Last) {
+			group.Last, group.Example = entry.Time, entry.Error
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Last.After(groups[j].Last)
+	})
+	return groups, nil
+}
+
+// groupKey returns function that extracts value of group key from entry
+func groupKey(by string) (func(Entry) (string, bool), error) {
+	switch by {
+	case ByFingerprint:
+		return func(e Entry) (string, bool) { return e.Fingerprint, true }, nil
+	case ByType:
+		return func(e Entry) (string, bool) { return e.Stack[0].Type, true }, nil
+	case ByLevel:
+		return func(e Entry) (string, bool) { return e.Stack[0].Level, true }, nil
+	case BySeverity:
+		return func(e Entry) (string, bool) { return e.Stack[0].Severity, true }, nil
+	case ByCode:
+		return func(e Entry) (string, bool) { return e.Stack[0].Code, true }, nil
+	}
+	if key := strings.TrimPrefix(by, ByBaggage); key != by && key != "" {
+		return func(e Entry) (string, bool) { return BaggageValue(e, key) }, nil
+	}
+	return nil, fmt.Errorf("unknown group key %q", by)
+}
@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Failpoint describes error returned by Inject for enabled failpoint
+type Failpoint struct {
+	Type     ErrorType
+	Level    ErrorLevel
+	Severity ErrorSeverity
+	// Message of error, "failpoint <name>" is used if not set
+	Message ErrorMessage
+	// Probability of error in range (0, 1], error is returned on every call if it is not set
+	Probability float64
+	// Count is a max count of returned errors, 0 means unlimited
+	Count int
+}
+
+// FailpointState is an enabled failpoint with count of returned errors
+type FailpointState struct {
+	Name string
+	Failpoint
+	Hits int
+}
+
+// failpoints contain enabled failpoints, enabled is a count of them that allows Inject to skip locking
+var failpoints = struct {
+	sync.Mutex
+	enabled int32
+	points  map[string]*FailpointState
+}{points: make(map[string]*FailpointState)}
+
+// EnableFailpoint enables failpoint, so Inject with the same name starts to return errors.
+// Failpoint that is already enabled is replaced and its hits are reset
+func EnableFailpoint(name string, failpoint Failpoint) {
+	failpoints.Lock()
+	defer failpoints.Unlock()
+	failpoints.points[name] = &FailpointState{Name: name, Failpoint: failpoint}
+	atomic.StoreInt32(&failpoints.enabled, int32(len(failpoints.points)))
+}
+
+// DisableFailpoint disables failpoint, unknown names are ignored
+func DisableFailpoint(name string) {
+	failpoints.Lock()
+	defer failpoints.Unlock()
+	delete(failpoints.points, name)
+	atomic.StoreInt32(&failpoints.enabled, int32(len(failpoints.points)))
+}
+
+// DisableFailpoints disables all failpoints
+func DisableFailpoints() {
+	failpoints.Lock()
+	defer failpoints.Unlock()
+	failpoints.points = make(map[string]*FailpointState)
+	atomic.StoreInt32(&failpoints.enabled, 0)
+}
+
+// Failpoints returns enabled failpoints sorted by name
+func Failpoints() []FailpointState {
+	failpoints.Lock()
+	defer failpoints.Unlock()
+	result := make([]FailpointState, 0, len(failpoints.points))
+	for _, v := range failpoints.points {
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Inject returns error of enabled failpoint with the name, nil is returned if failpoint is disabled,
+// count limit of failpoint is reached or error is skipped by probability. Failpoints let tests and chaos runs
+// check error paths through every layer without mocks, name is stored in "failpoint" baggage key
+//
+//	func (r *Repo) LoadUser(ctx context.Context, id int) (*User, error) {
+//		if err := cErrors.Inject("repo.user.load"); err != nil {
+//			return nil, err
+//		}
+//		//....
+//	}
+//
+// Inject is a single atomic load when there are no enabled failpoints
+func Inject(name string) CustomError {
+	if atomic.LoadInt32(&failpoints.enabled) == 0 {
+		return nil
+	}
+
+	failpoints.Lock()
+	state, ok := failpoints.points[name]
+	if !ok || (state.Count > 0 && state.Hits >= state.Count) ||
+		(state.Probability > 0 && rand.Float64() >= state.Probability) {
+		failpoints.Unlock()
+		return nil
+	}
+	state.Hits++
+	failpoint := state.Failpoint
+	failpoints.Unlock()
+
+	message := failpoint.Message
+	if message == "" {
+		message = ErrorMessage("failpoint " + name)
+	}
+	// the stack starts from Inject, so path of error is the place of Inject call
+	wrappedErr := &stackErr{err: &transparent{err: errors.New(message.String())}, stack: callers(0)}
+	return newCustomErr(failpoint.Type, ErrorBaggage{"failpoint": name}, failpoint.Level, failpoint.Severity, wrappedErr)
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadProfile() error {
+	if err := Inject("repo.user.load"); err != nil {
+		return Wrap(err, "load profile")
+	}
+	return nil
+}
+
+func TestInject(t *testing.T) {
+	assertions := assert.New(t)
+	defer DisableFailpoints()
+
+	assertions.NoError(loadProfile(), "Check disabled failpoint")
+
+	EnableFailpoint("repo.user.load", Failpoint{Type: Unavailable, Level: DataLevel, Severity: Critical, Count: 2})
+	EnableFailpoint("repo.order.load", Failpoint{Message: "orders are unavailable"})
+	err := loadProfile()
+	if assertions.Error(err) {
+		assertions.Equal("load profile: failpoint repo.user.load", err.Error())
+		customErr := err.(CustomError)
+		assertions.Equal(Unavailable, customErr.GetType())
+		assertions.Equal(DataLevel, customErr.GetLevel())
+		assertions.Equal(Critical, customErr.GetSeverity())
+
+		base, _ := customErr.Unwrap().(CustomError)
+		assertions.Equal("repo.user.load", base.GetBaggage()["failpoint"])
+		assertions.True(strings.HasPrefix(base.GetPath().String(), "github.com/Darevski/go-custom-errors.loadProfile\n"), "Check path of Inject call")
+		assertions.Equal("failpoint repo.user.load", Cause(customErr).Error())
+	}
+	assertions.Error(loadProfile())
+	assertions.NoError(loadProfile(), "Check count limit")
+	assertions.Equal("orders are unavailable", Inject("repo.order.load").Error())
+
+	states := Failpoints()
+	if assertions.Len(states, 2) {
+		assertions.Equal("repo.order.load", states[0].Name)
+		assertions.Equal(1, states[0].Hits)
+		assertions.Equal(2, states[1].Hits)
+	}
+
+	DisableFailpoint("repo.order.load")
+	assertions.Nil(Inject("repo.order.load"))
+	assertions.Len(Failpoints(), 1)
+}
+
+func TestInject_Probability(t *testing.T) {
+	assertions := assert.New(t)
+	defer DisableFailpoints()
+
+	EnableFailpoint("flaky", Failpoint{Probability: 0.5})
+	hits := 0
+	for k := 0; k < 1000; k++ {
+		if Inject("flaky") != nil {
+			hits++
+		}
+	}
+	assertions.InDelta(500, hits, 150)
+	assertions.Equal(hits, Failpoints()[0].Hits)
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+)
+
+// fingerprintLength is a count of hex symbols in the error fingerprint
+const fingerprintLength = 16
+
+// Fingerprint returns a short stable hash of error that can be used to group the same errors together.
+// Hash is based on the error type and the paths of every layer in the error stack, so errors
+// that differ only by message arguments (ids, names etc.) have the same fingerprint
+func Fingerprint(err CustomError) string {
+	hash := sha1.New()
+	_, _ = io.WriteString(hash, err.GetType().String())
+
+	stack := make([]CustomError, 0)
+	err.getStack(&stack)
+	for _, v := range stack {
+		_, _ = io.WriteString(hash, "\n")
+		if path := v.GetPath(); path != "" {
+			_, _ = io.WriteString(hash, path.String())
+		} else {
+			_, _ = io.WriteString(hash, v.GetMessage().String())
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:fingerprintLength]
+}
@@ -1,8 +1,10 @@
 module github.com/Darevski/go-custom-errors
 
-go 1.14
+go 1.16
 
 require (
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,104 @@
+package httperr
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Transport is http.RoundTripper that turns 4xx and 5xx responses into CustomError received from the remote service.
+// Error is returned instead of the response, response body is closed
+type Transport struct {
+	// Base is used to make requests, http.DefaultTransport is used if nil
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	if customErr := Decode(resp); customErr != nil {
+		_ = resp.Body.Close()
+		return nil, customErr
+	}
+	return resp, nil
+}
+
+// Decode returns CustomError of 4xx or 5xx response or nil for other ones, e.g. 304 Not Modified and redirects are not errors.
+// If response has not error headers then error type is chosen by status code
+func Decode(resp *http.Response) cErrors.CustomError {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	header := resp.Header
+	service := header.Get(HeaderService)
+	if service == "" && resp.Request != nil && resp.Request.URL != nil {
+		service = resp.Request.URL.Host
+	}
+
+	record := cErrors.ErrorRecord{Error: resp.Status}
+	for _, v := range header.Values(HeaderChain) {
+		values, err := url.ParseQuery(v)
+		if err != nil {
+			continue
+		}
+		record.Stack = append(record.Stack, cErrors.LayerRecord{
+			Message:       values.Get("message"),
+			Type:          values.Get("type"),
+			Level:         values.Get("level"),
+			Severity:      values.Get("severity"),
+			Code:          values.Get("code"),
+			PublicMessage: values.Get("public"),
+			PublicCode:    values.Get("publicCode"),
+		})
+	}
+	if len(record.Stack) == 0 {
+		record.Stack = append(record.Stack, cErrors.LayerRecord{
+			Message:  resp.Status,
+			Type:     headerOr(header, HeaderType, TypeFromStatus(resp.StatusCode).String()),
+			Level:    header.Get(HeaderLevel),
+			Severity: header.Get(HeaderSeverity),
+		})
+	}
+	if cause, err := url.QueryUnescape(header.Get(HeaderCause)); err == nil {
+		record.Cause = cause
+	}
+
+	baggage := make(cErrors.ErrorBaggage)
+	for _, v := range header.Values(HeaderBaggage) {
+		values, err := url.ParseQuery(v)
+		if err != nil {
+			continue
+		}
+		for key := range values {
+			baggage[key] = values.Get(key)
+		}
+	}
+	if len(baggage) > 0 {
+		record.Stack[0].Baggage = baggage
+	}
+
+	return cErrors.NewRemote(service, record)
+}
+
+func headerOr(header http.Header, key, fallback string) string {
+	if value := header.Get(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
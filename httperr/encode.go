@@ -0,0 +1,133 @@
+// Package httperr propagates custom errors between services over HTTP headers.
+//
+// Server side uses Encoder to put error metadata into response headers,
+// client side uses Transport (or Decode) to restore CustomError from a failed response
+//
+//	client := &http.Client{Transport: &httperr.Transport{}}
+//	if _, err := client.Get(url); errors.Is(err, cErrors.NotFound.NewBase("")) {
+//		//....
+//	}
+package httperr
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Headers used for error propagation
+const (
+	// HeaderService contains name of service where error happened
+	HeaderService = "X-Error-Service"
+	// HeaderType, HeaderLevel, HeaderSeverity describe the top error of the chain
+	HeaderType     = "X-Error-Type"
+	HeaderLevel    = "X-Error-Level"
+	HeaderSeverity = "X-Error-Severity"
+	// HeaderChain is repeated for every layer of the error stack from the top one,
+	// value is url encoded type, level, severity, sentinel code, message and public attributes.
+	// Only single layer with public message and code is sent for public exposure
+	HeaderChain = "X-Error-Chain"
+	// HeaderCause contains message of the original error, it is not sent for public exposure
+	HeaderCause = "X-Error-Cause"
+	// HeaderBaggage is repeated for every allowlisted baggage key, value is url encoded key and value
+	HeaderBaggage = "X-Error-Baggage"
+)
+
+// Encoder writes CustomError metadata into HTTP response headers
+type Encoder struct {
+	// Service is a name of the current service
+	Service string
+	// Baggage is an allowlist of baggage keys that could be sent to the client,
+	// baggage is not sent if allowlist is empty
+	Baggage []string
+	// Exposure defines whether internal messages are sent, only public messages are sent by default.
+	// Use ExposeInternal for trusted clients, e.g. other services
+	Exposure cErrors.Exposure
+}
+
+// Encode writes error headers and status code based on error type
+func (e Encoder) Encode(w http.ResponseWriter, err cErrors.CustomError) {
+	e.SetHeaders(w.Header(), err)
+	w.WriteHeader(StatusCode(err.GetType()))
+}
+
+// SetHeaders writes error metadata into headers
+func (e Encoder) SetHeaders(header http.Header, err cErrors.CustomError) {
+	record := cErrors.NewErrorRecord(err)
+
+	if e.Service != "" {
+		header.Set(HeaderService, e.Service)
+	}
+	header.Set(HeaderType, err.GetType().String())
+	header.Set(HeaderLevel, err.GetLevel().String())
+	header.Set(HeaderSeverity, err.GetSeverity().String())
+
+	header.Del(HeaderChain)
+	header.Del(HeaderCause)
+	if e.Exposure != cErrors.ExposeInternal {
+		// public message replaces internal message, so it is also the message of decoded error
+		public := cErrors.PublicMessage(err).String()
+		header.Add(HeaderChain, encodeLayer(cErrors.LayerRecord{
+			Type:          err.GetType().String(),
+			Level:         err.GetLevel().String(),
+			Severity:      err.GetSeverity().String(),
+			Message:       public,
+			PublicMessage: public,
+			PublicCode:    cErrors.PublicCode(err).String(),
+		}))
+	} else {
+		for _, v := range record.Stack {
+			header.Add(HeaderChain, encodeLayer(v))
+		}
+		if record.Cause != "" {
+			header.Set(HeaderCause, url.QueryEscape(record.Cause))
+		}
+	}
+
+	header.Del(HeaderBaggage)
+	for _, v := range e.baggage(record) {
+		header.Add(HeaderBaggage, v)
+	}
+}
+
+// encodeLayer returns layer of error stack in url encoded form, empty attributes are omitted
+func encodeLayer(layer cErrors.LayerRecord) string {
+	values := url.Values{
+		"type":     {layer.Type},
+		"level":    {layer.Level},
+		"severity": {layer.Severity},
+		"message":  {layer.Message},
+	}
+	optional := map[string]string{
+		"code":       layer.Code,
+		"public":     layer.PublicMessage,
+		"publicCode": layer.PublicCode,
+	}
+	for key, value := range optional {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values.Encode()
+}
+
+// baggage returns allowlisted baggage of the whole chain in encoded form, upper layers override values of lower ones
+func (e Encoder) baggage(record cErrors.ErrorRecord) []string {
+	values := make(map[string]interface{})
+	for k := len(record.Stack) - 1; k >= 0; k-- {
+		for _, key := range e.Baggage {
+			if value, ok := record.Stack[k].Baggage[key]; ok {
+				values[key] = value
+			}
+		}
+	}
+
+	result := make([]string, 0, len(values))
+	for key, value := range values {
+		result = append(result, url.Values{key: {toString(value)}}.Encode())
+	}
+	sort.Strings(result)
+	return result
+}
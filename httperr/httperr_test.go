@@ -0,0 +1,213 @@
+package httperr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	assertions := assert.New(t)
+	encoder := Encoder{Service: "users", Baggage: []string{"userID"}, Exposure: cErrors.ExposeInternal}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := cErrors.NotFound.New(cErrors.DataLevel, cErrors.ErrorBaggage{"userID": 42, "sql": "select"}, cErrors.Warning, "no rows")
+		wrapped := cErrors.Wrap(err, "load user").SetLevel(cErrors.UseCaseLevel)
+		wrapped = cErrors.Wrap(wrapped, "get user")
+		encoder.Encode(w, wrapped)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	_, err := client.Get(server.URL)
+	assertions.Error(err)
+	assertions.True(errors.Is(err, cErrors.NotFound.NewBase("")), "Check errors.Is with remote error")
+	assertions.False(errors.Is(err, cErrors.InternalError.NewBase("")), "Check errors.Is with remote error")
+	assertions.True(cErrors.IsRemote(err))
+	service, ok := cErrors.RemoteService(err)
+	assertions.True(ok)
+	assertions.Equal("users", service)
+
+	var customErr cErrors.CustomError
+	assertions.True(errors.As(err, &customErr))
+	assertions.Equal("get user: load user: no rows", customErr.Error())
+	assertions.Equal(cErrors.ErrorMessage("get user"), customErr.GetMessage())
+	assertions.Equal(cErrors.UseCaseLevel, customErr.GetLevel())
+	assertions.Equal(cErrors.Warning, customErr.GetSeverity())
+	assertions.Equal(cErrors.ErrorBaggage{"userID": "42"}, customErr.GetBaggage(), "Check baggage allowlist")
+	assertions.True(customErr.IsMessageExistInStack("load user"))
+	assertions.True(customErr.IsMessageExistInStack("no rows"))
+
+	record := cErrors.NewErrorRecord(customErr)
+	assertions.Len(record.Stack, 3)
+	assertions.Equal("DataLevel", record.Stack[2].Level)
+	assertions.Equal("no rows", record.Cause)
+}
+
+func TestTransport_NotModified(t *testing.T) {
+	assertions := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport:     &Transport{},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	resp, err := client.Do(req)
+	if assertions.NoError(err) {
+		assertions.Equal(http.StatusNotModified, resp.StatusCode)
+		_ = resp.Body.Close()
+	}
+
+	resp, err = client.Get(server.URL)
+	if assertions.NoError(err, "Check redirect is not an error") {
+		assertions.Equal(http.StatusFound, resp.StatusCode)
+		_ = resp.Body.Close()
+	}
+}
+
+func TestDecode(t *testing.T) {
+	assertions := assert.New(t)
+
+	for _, code := range []int{http.StatusOK, http.StatusFound, http.StatusNotModified} {
+		resp := &http.Response{StatusCode: code, Status: http.StatusText(code), Header: http.Header{}}
+		assertions.Nil(Decode(resp), "Check that %d response is not an error", code)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}}
+
+	resp = &http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Header: http.Header{}}
+	err := Decode(resp)
+	assertions.Equal(cErrors.AccessDenied, err.GetType(), "Check type by status code")
+	assertions.Equal("403 Forbidden", err.Error())
+	assertions.True(cErrors.IsRemote(err))
+
+	resp = &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway", Header: http.Header{}}
+	assertions.Equal(cErrors.Unavailable, Decode(resp).GetType())
+
+	resp = &http.Response{StatusCode: http.StatusInsufficientStorage, Status: "507 Insufficient Storage", Header: http.Header{}}
+	assertions.Equal(cErrors.InternalError, Decode(resp).GetType(), "Check type of unknown 5xx status code")
+}
+
+func TestEncoder_SetHeaders(t *testing.T) {
+	assertions := assert.New(t)
+	header := http.Header{}
+
+	err := cErrors.New(cErrors.AccessDenied, cErrors.ControllerLevel, cErrors.ErrorBaggage{"token": "secret"}, cErrors.Info, "denied")
+	Encoder{Exposure: cErrors.ExposeInternal}.SetHeaders(header, err)
+	assertions.Equal("AccessDenied", header.Get(HeaderType))
+	assertions.Equal("ControllerLevel", header.Get(HeaderLevel))
+	assertions.Equal("Info", header.Get(HeaderSeverity))
+	assertions.Equal([]string{"level=ControllerLevel&message=denied&severity=Info&type=AccessDenied"}, header.Values(HeaderChain))
+
+	sentinel := cErrors.NewSentinel("httperr.denied", cErrors.AccessDenied, "denied")
+	Encoder{Exposure: cErrors.ExposeInternal}.SetHeaders(header, cErrors.Wrap(sentinel, "check access"))
+	assertions.Contains(header.Values(HeaderChain)[0], "code=httperr.denied")
+	assertions.True(errors.Is(Decode(&http.Response{StatusCode: http.StatusForbidden, Header: header}), sentinel),
+		"Check that sentinel code is propagated")
+	assertions.Empty(header.Get(HeaderService))
+	assertions.Empty(header.Values(HeaderBaggage), "Check that baggage is not sent without allowlist")
+	assertions.Equal(http.StatusForbidden, StatusCode(err.GetType()))
+}
+
+func TestStatusCode(t *testing.T) {
+	assertions := assert.New(t)
+	paymentRequired := cErrors.ErrorType(1000)
+	teapot := cErrors.ErrorType(1001)
+	cErrors.SetTypeParent(paymentRequired, cErrors.ClientError)
+	cErrors.SetTypeParent(teapot, cErrors.NotFound)
+
+	assertions.Equal(http.StatusNotFound, StatusCode(cErrors.NotFound))
+	assertions.Equal(http.StatusBadRequest, StatusCode(paymentRequired), "Check status of parent category")
+	assertions.Equal(http.StatusNotFound, StatusCode(teapot), "Check status of parent type")
+	assertions.Equal(http.StatusInternalServerError, StatusCode(cErrors.ErrorType(1002)), "Check unknown type")
+
+	assertions.Equal(cErrors.ClientError, TypeFromStatus(http.StatusTeapot))
+	assertions.Equal(cErrors.DefaultType, TypeFromStatus(http.StatusFound))
+}
+
+func TestWriteProblem(t *testing.T) {
+	assertions := assert.New(t)
+	recorder := httptest.NewRecorder()
+
+	err := cErrors.NewValidation().Add("items[0].price", "min", "price must be positive", -1).Err()
+	WriteProblem(recorder, cErrors.Wrap(err, "create order"))
+
+	assertions.Equal(http.StatusBadRequest, recorder.Code)
+	assertions.Equal(ProblemContentType, recorder.Header().Get("Content-Type"))
+	assertions.JSONEq(`{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "Invalid arguments",
+		"code": "InvalidArguments",
+		"violations": [{"field": "items[0].price", "rule": "min", "message": "price must be positive", "value": -1}]
+	}`, recorder.Body.String())
+}
+
+func TestPublicExposure(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := cErrors.WrapWith(cErrors.NotFound.NewBase("select from users: no rows"), "get user",
+		cErrors.WithType(cErrors.NotFound), cErrors.WithPublicMessage("User not found"), cErrors.WithPublicCode("user.not_found"))
+
+	header := make(http.Header)
+	Encoder{}.SetHeaders(header, err)
+	assertions.Equal([]string{"level=DefaultLevel&message=User+not+found&public=User+not+found&publicCode=user.not_found&severity=DefaultSeverity&type=NotFound"},
+		header.Values(HeaderChain), "Check only public layer is sent")
+	assertions.Empty(header.Get(HeaderCause))
+
+	remote := Decode(&http.Response{StatusCode: http.StatusNotFound, Header: header})
+	if assertions.NotNil(remote) {
+		assertions.Equal("User not found", remote.Error(), "Check internal messages are not sent")
+		assertions.Equal(cErrors.ErrorMessage("User not found"), remote.GetPublicMessage())
+		assertions.Equal(cErrors.ErrorCode("user.not_found"), remote.GetPublicCode())
+		assertions.Empty(remote.GetCode(), "Check public code is not decoded as sentinel code")
+	}
+
+	problem := Renderer{}.Problem(err)
+	assertions.Equal("User not found", problem.Detail)
+	assertions.Equal("user.not_found", problem.Code)
+	assertions.Nil(problem.Internal)
+
+	problem = Renderer{Exposure: cErrors.ExposeInternal}.Problem(err)
+	assertions.Equal(err.Error(), problem.Detail)
+	if assertions.NotNil(problem.Internal) {
+		assertions.Len(problem.Internal.Stack, 2)
+	}
+
+	header = make(http.Header)
+	Encoder{Exposure: cErrors.ExposeInternal}.SetHeaders(header, err)
+	remote = Decode(&http.Response{StatusCode: http.StatusNotFound, Header: header})
+	assertions.Equal(cErrors.ErrorMessage("User not found"), cErrors.PublicMessage(remote), "Check public message is propagated")
+	assertions.Equal(cErrors.ErrorCode("user.not_found"), cErrors.PublicCode(remote))
+}
+
+func TestLocalizedProblem(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := cErrors.NewCatalog("en")
+	catalog.Add("en", map[string]cErrors.Template{"NotFound": {Message: "Nothing found"}})
+	catalog.Add("de", map[string]cErrors.Template{"NotFound": {Message: "Nichts gefunden"}})
+
+	assertions.Equal([]string{"fr-CH", "de", "en"}, AcceptLanguage("en;q=0.5, de;q=0.9, *;q=0.1, fr-CH, ru;q=0"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr-CH, de-AT;q=0.9, en;q=0.5")
+	recorder := httptest.NewRecorder()
+	Renderer{Catalog: catalog}.ForRequest(req).WriteProblem(recorder, cErrors.NotFound.NewBase("select from users"))
+
+	assertions.Equal("de", recorder.Header().Get("Content-Language"))
+	assertions.Contains(recorder.Body.String(), `"detail":"Nichts gefunden"`)
+}
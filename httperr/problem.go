@@ -0,0 +1,122 @@
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// ProblemContentType is a content type of problem details responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem details response body described in RFC 7807
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Code is a public code of error
+	Code string `json:"code,omitempty"`
+	// Violations are field violations of InvalidArguments error
+	Violations []cErrors.Violation `json:"violations,omitempty"`
+	// Internal is full error representation, it is set only for internal exposure
+	Internal *cErrors.ErrorRecord `json:"internal,omitempty"`
+}
+
+// Renderer writes errors as problem details responses
+type Renderer struct {
+	// Exposure defines whether internal messages are rendered, only public messages are rendered by default
+	Exposure cErrors.Exposure
+	// Catalog localizes public messages, cErrors.DefaultCatalog is used if nil
+	Catalog *cErrors.Catalog
+	// Locale of public messages, messages are not localized if it is empty. See ForRequest
+	Locale string
+}
+
+// ForRequest returns renderer with the best locale of catalog for Accept-Language header of request
+func (r Renderer) ForRequest(req *http.Request) Renderer {
+	r.Locale = r.catalog().Match(AcceptLanguage(req.Header.Get("Accept-Language"))...)
+	return r
+}
+
+func (r Renderer) catalog() *cErrors.Catalog {
+	if r.Catalog == nil {
+		return cErrors.DefaultCatalog
+	}
+	return r.Catalog
+}
+
+// Problem create problem details of error
+func (r Renderer) Problem(err cErrors.CustomError) Problem {
+	status := StatusCode(err.GetType())
+	public := cErrors.NewPublicError(err, r.Exposure)
+	if r.Locale != "" && r.Exposure != cErrors.ExposeInternal {
+		public.Message = r.catalog().Localize(err, r.Locale)
+	}
+	return Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     public.Message,
+		Code:       public.Code.String(),
+		Violations: public.Violations,
+		Internal:   public.Internal,
+	}
+}
+
+// WriteProblem writes problem details of error as application/problem+json response
+func (r Renderer) WriteProblem(w http.ResponseWriter, err cErrors.CustomError) {
+	problem := r.Problem(err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	if r.Locale != "" {
+		w.Header().Set("Content-Language", r.Locale)
+	}
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// NewProblem create problem details of error with public messages only
+func NewProblem(err cErrors.CustomError) Problem {
+	return Renderer{}.Problem(err)
+}
+
+// WriteProblem writes problem details of error with public messages only
+func WriteProblem(w http.ResponseWriter, err cErrors.CustomError) {
+	Renderer{}.WriteProblem(w, err)
+}
+
+// AcceptLanguage returns locales of Accept-Language header value ordered by quality, e.g. "fr-CH, fr;q=0.9, *;q=0.5".
+// Wildcard and locales with zero quality are skipped
+func AcceptLanguage(value string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var locales []weighted
+	for _, part := range strings.Split(value, ",") {
+		params := strings.Split(part, ";")
+		locale := strings.TrimSpace(params[0])
+		quality := 1.0
+		for _, param := range params[1:] {
+			if q := strings.TrimSpace(param); strings.HasPrefix(q, "q=") {
+				if parsed, err := strconv.ParseFloat(q[2:], 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if locale != "" && locale != "*" && quality > 0 {
+			locales = append(locales, weighted{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(locales, func(i, j int) bool { return locales[i].quality > locales[j].quality })
+
+	result := make([]string, 0, len(locales))
+	for _, v := range locales {
+		result = append(result, v.locale)
+	}
+	return result
+}
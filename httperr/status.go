@@ -0,0 +1,15 @@
+package httperr
+
+import (
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// StatusCode returns HTTP status code for error type, see errors.ErrorType.HTTPStatus
+func StatusCode(errType cErrors.ErrorType) int {
+	return errType.HTTPStatus()
+}
+
+// TypeFromStatus returns error type for HTTP status code, see errors.TypeFromHTTPStatus
+func TypeFromStatus(status int) cErrors.ErrorType {
+	return cErrors.TypeFromHTTPStatus(status)
+}
@@ -0,0 +1,273 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// placeholder is a template parameter, e.g. {userID}
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// Template is a localized message. Parameters in braces, e.g. "User {userID} not found", are replaced with
+// baggage values of error. Template with plural forms chooses the form by integer baggage value named by Count
+type Template struct {
+	// Message is used when there are no plural forms or suitable form is absent
+	Message string
+	// Count is a name of parameter that chooses plural form
+	Count string
+	// Forms are messages by plural form
+	Forms map[PluralForm]string
+}
+
+// templateObject is a serialized form of template with plural forms, e.g. {"count": "n", "one": "...", "other": "..."}
+type templateObject map[string]string
+
+// UnmarshalJSON implements json.Unmarshaler, template is a string or an object with count and plural forms
+func (t *Template) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*t = Template{Message: message}
+		return nil
+	}
+	var object templateObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*t = object.template()
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, template is a string or a mapping with count and plural forms
+func (t *Template) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*t = Template{Message: value.Value}
+		return nil
+	}
+	var object templateObject
+	if err := value.Decode(&object); err != nil {
+		return err
+	}
+	*t = object.template()
+	return nil
+}
+
+func (o templateObject) template() Template {
+	result := Template{Count: o["count"], Message: o["message"], Forms: make(map[PluralForm]string)}
+	for key, value := range o {
+		if key != "count" && key != "message" {
+			result.Forms[PluralForm(key)] = value
+		}
+	}
+	if result.Message == "" {
+		result.Message = result.Forms[PluralOther]
+	}
+	return result
+}
+
+// render returns message with replaced parameters, unknown parameters are kept as is
+func (t Template) render(locale string, params ErrorBaggage) string {
+	message := t.Message
+	if count, ok := toInt(params[t.Count]); ok && t.Count != "" {
+		if form, found := t.Forms[Plural(locale, count)]; found {
+			message = form
+		}
+	}
+	return placeholder.ReplaceAllStringFunc(message, func(s string) string {
+		if value, ok := params[s[1:len(s)-1]]; ok {
+			return fmt.Sprint(value)
+		}
+		return s
+	})
+}
+
+// Catalog contains localized message templates by locale. Templates are keyed by error code
+// (public or sentinel one) or by ErrorType name, e.g. "user.not_found" or "NotFound"
+type Catalog struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	templates     map[string]map[string]Template
+}
+
+// DefaultCatalog is used by Localize
+var DefaultCatalog = NewCatalog("en")
+
+// NewCatalog create empty catalog, default locale finishes fallback chain of every locale
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{defaultLocale: normalizeLocale(defaultLocale), templates: make(map[string]map[string]Template)}
+}
+
+// Add adds templates of locale, existing templates with the same keys are replaced
+func (c *Catalog) Add(locale string, templates map[string]Template) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locale = normalizeLocale(locale)
+	if c.templates[locale] == nil {
+		c.templates[locale] = make(map[string]Template)
+	}
+	for key, template := range templates {
+		c.templates[locale][key] = template
+	}
+}
+
+// LoadJSON adds templates of locale from JSON object
+func (c *Catalog) LoadJSON(locale string, data []byte) error {
+	templates := make(map[string]Template)
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return WrapWith(err, "decode catalog", WithType(InvalidArguments), WithBaggage(ErrorBaggage{"locale": locale}))
+	}
+	c.Add(locale, templates)
+	return nil
+}
+
+// LoadYAML adds templates of locale from YAML mapping
+func (c *Catalog) LoadYAML(locale string, data []byte) error {
+	templates := make(map[string]Template)
+	if err := yaml.Unmarshal(data, &templates); err != nil {
+		return WrapWith(err, "decode catalog", WithType(InvalidArguments), WithBaggage(ErrorBaggage{"locale": locale}))
+	}
+	c.Add(locale, templates)
+	return nil
+}
+
+// LoadFS adds templates from .json, .yaml and .yml files of directory, file name is a locale, e.g. "pt-BR.yaml".
+// It is intended for embedded catalogs:
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	err := errors.DefaultCatalog.LoadFS(locales, "locales")
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return WrapWith(err, "read catalog directory", WithType(NotFound))
+	}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return WrapWith(err, "read catalog file", WithType(InternalError))
+		}
+		locale := strings.TrimSuffix(entry.Name(), ext)
+		if ext == ".json" {
+			err = c.LoadJSON(locale, data)
+		} else {
+			err = c.LoadYAML(locale, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match returns the first locale of preferred ones supported by catalog, language of regional locale
+// is supported if catalog has it. Default locale is returned if there are no supported locales
+func (c *Catalog) Match(preferred ...string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, locale := range preferred {
+		for _, candidate := range localeChain(locale) {
+			if _, ok := c.templates[candidate]; ok {
+				return candidate
+			}
+		}
+	}
+	return c.defaultLocale
+}
+
+// Localize returns localized public message of error. Templates are looked up by public code and sentinel codes,
+// then explicit public message of error (see WithPublicMessage) is used, then templates of error type and its parents
+// are looked up, so generic type templates never replace explicit messages.
+// Every lookup follows the locale fallback chain, e.g. "pt-BR", "pt" and the default locale.
+// PublicMessage is returned if there are no templates
+func (c *Catalog) Localize(err error, locale string) string {
+	params := chainBaggage(err)
+	layers := FindAllInStack(err, func(CustomError) bool { return true })
+
+	var codes []string
+	for _, layer := range layers {
+		if code := layer.GetPublicCode(); code != "" {
+			codes = append(codes, code.String())
+		}
+	}
+	for _, layer := range layers {
+		if code := layer.GetCode(); code != "" {
+			codes = append(codes, code.String())
+		}
+	}
+	if template, ok := c.lookup(locale, codes); ok {
+		return template.render(locale, params)
+	}
+	if layer, ok := FindInStack(err, func(e CustomError) bool { return e.GetPublicMessage() != "" }); ok {
+		return layer.GetPublicMessage().String()
+	}
+
+	var types []string
+	for errType, ok := topType(err), true; ok; errType, ok = errType.Parent() {
+		types = append(types, errType.String())
+	}
+	if template, ok := c.lookup(locale, types); ok {
+		return template.render(locale, params)
+	}
+	return PublicMessage(err).String()
+}
+
+// lookup returns the first found template of keys following the locale fallback chain
+func (c *Catalog) lookup(locale string, keys []string) (Template, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range append(localeChain(locale), c.defaultLocale) {
+		for _, key := range keys {
+			if template, ok := c.templates[candidate][key]; ok {
+				return template, true
+			}
+		}
+	}
+	return Template{}, false
+}
+
+// Localize returns localized public message of error using DefaultCatalog
+func Localize(err error, locale string) string {
+	return DefaultCatalog.Localize(err, locale)
+}
+
+// chainBaggage returns baggage of the whole error chain, upper layers override values of lower ones
+func chainBaggage(err error) ErrorBaggage {
+	layers := FindAllInStack(err, func(CustomError) bool { return true })
+	result := make(ErrorBaggage)
+	for k := len(layers) - 1; k >= 0; k-- {
+		for key, value := range layers[k].GetBaggage() {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// localeChain returns normalized locale and its parents, e.g. "pt-br" and "pt" for "pt_BR"
+func localeChain(locale string) []string {
+	locale = normalizeLocale(locale)
+	var result []string
+	for locale != "" {
+		result = append(result, locale)
+		k := strings.LastIndex(locale, "-")
+		if k < 0 {
+			break
+		}
+		locale = locale[:k]
+	}
+	return result
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed testdata/locales
+var testLocales embed.FS
+
+func newTestCatalog(t *testing.T) *Catalog {
+	catalog := NewCatalog("en")
+	assert.NoError(t, catalog.LoadFS(testLocales, "testdata/locales"))
+	return catalog
+}
+
+func TestLocalize(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := newTestCatalog(t)
+
+	err := Wrap(errUserNotFound, "load user").AddBaggage(ErrorBaggage{"userID": 42})
+	assertions.Equal("User 42 not found", catalog.Localize(err, "en"))
+	assertions.Equal("Пользователь 42 не найден", catalog.Localize(err, "ru-RU"), "Check regional locale falls back to language")
+	assertions.Equal("User 42 not found", catalog.Localize(err, "de"), "Check default locale fallback")
+
+	notFound := NotFound.NewBase("select from orders")
+	assertions.Equal("Recurso não encontrado", catalog.Localize(notFound, "pt_BR"))
+	assertions.Equal("Request is invalid", catalog.Localize(BadRequest.NewBase("bad"), "ru"), "Check template of parent type")
+	assertions.Equal("Order is archived",
+		catalog.Localize(WrapWith(notFound, "load order", WithPublicMessage("Order is archived")), "ru"), "Check explicit public message")
+	assertions.Equal("Internal error", catalog.Localize(InternalError.NewBase("panic"), "en"), "Check public message fallback")
+}
+
+func TestLocalizeExplicitMessage(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := newTestCatalog(t)
+	catalog.Add("de", map[string]Template{"test.i18n.user_blocked": {Message: "Benutzer {userID} ist gesperrt"}})
+
+	// generated errors have code and explicit public message, see cmd/errgen
+	err := Wrap(Make("user 42 is blocked", WithType(NotFound), WithCode("test.i18n.user_blocked"),
+		WithPublicMessage("User is blocked"), WithBaggage(ErrorBaggage{"userID": 42})), "load user")
+	assertions.Equal("Benutzer 42 ist gesperrt", catalog.Localize(err, "de-AT"), "Check that code template wins over explicit message")
+	assertions.Equal("User is blocked", catalog.Localize(err, "ru"), "Check that explicit message wins over type template")
+	assertions.Equal("User is blocked", catalog.Localize(err, "en"))
+}
+
+func TestLocalizePlural(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := newTestCatalog(t)
+	limit := func(n interface{}) CustomError {
+		return Make("cart is full", WithType(Conflict), WithPublicCode("cart.limit"), WithBaggage(ErrorBaggage{"limit": n}))
+	}
+
+	assertions.Equal("You can add only 1 item", catalog.Localize(limit(1), "en"))
+	assertions.Equal("You can add only 5 items", catalog.Localize(limit(5), "en-GB"))
+	assertions.Equal("Можно добавить только 21 товар", catalog.Localize(limit(21), "ru"))
+	assertions.Equal("Можно добавить только 3 товара", catalog.Localize(limit(float64(3)), "ru"), "Check decoded JSON number")
+	assertions.Equal("Можно добавить только 11 товаров", catalog.Localize(limit("11"), "ru"))
+
+	assertions.Equal(PluralMany, Plural("pl", 5))
+	assertions.Equal(PluralOther, Plural("unknown", 1))
+}
+
+func TestCatalogMatch(t *testing.T) {
+	assertions := assert.New(t)
+	catalog := newTestCatalog(t)
+
+	assertions.Equal("ru", catalog.Match("de", "ru-RU", "en"))
+	assertions.Equal("pt", catalog.Match("pt-BR"))
+	assertions.Equal("en", catalog.Match("de"))
+	assertions.Error(catalog.LoadJSON("en", []byte(`[]`)))
+}
@@ -34,9 +34,25 @@ const (
 
 	AccessDenied
 	Unauthorized
+
+	// ClientError is a category of errors caused by the client, see SetTypeParent
+	ClientError
+	// ServerError is a category of errors caused by the server, see SetTypeParent
+	ServerError
+
+	Conflict
+	AlreadyExists
+	PreconditionFailed
+	RateLimited
+	Canceled
+
+	Timeout
+	Unavailable
+	NotImplemented
+	DataLoss
 )
 
-//go:generate stringer -type=ErrorType,ErrorLevel,ErrorSeverity -output=const.go
+//go:generate go run ./cmd/errgen -catalog errgen.yaml -output const.go -doc reference.md
 
 type stackTracer interface {
 	StackTrace() errs.StackTrace
@@ -72,8 +88,18 @@ type CustomError interface {
 	GetType() ErrorType
 	// GetMessage return error message value
 	GetMessage() ErrorMessage
+	// GetPublicMessage return message that could be shown to clients
+	GetPublicMessage() ErrorMessage
+	// SetPublicMessage set message that could be shown to clients
+	SetPublicMessage(message ErrorMessage) CustomError
+	// GetPublicCode return code that could be shown to clients
+	GetPublicCode() ErrorCode
+	// SetPublicCode set code that could be shown to clients
+	SetPublicCode(code ErrorCode) CustomError
 	// GetPath return file path of error
 	GetPath() ErrorPath
+	// GetCode return code of sentinel error, see NewSentinel
+	GetCode() ErrorCode
 	// GetSeverity return severity value
 	GetSeverity() ErrorSeverity
 	// SetSeverity set severity value
@@ -90,8 +116,12 @@ type CustomError interface {
 	// SetBaggage set baggage of error - fully rewrite exist baggage
 	SetBaggage(baggage ErrorBaggage) CustomError
 	// Is method is for errors.Is comparison supporting
-	// Compare errors by ErrorType
+	// Compare errors by ErrorType including parent types, sentinel errors are compared by code
 	Is(target error) bool
+	// IsClientError returns true if error type belongs to ClientError category
+	IsClientError() bool
+	// IsServerError returns true if error type belongs to ServerError category
+	IsServerError() bool
 	// getStack return slice of CustomError that represent error stacktrace
 	getStack(result *[]CustomError)
 	// IsMessageExistInStack represent is error stack contains error with specified message or not
@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ErrorRecord is a serializable representation of CustomError with the whole error stack
+type ErrorRecord struct {
+	// Fingerprint of error, see Fingerprint function
+	Fingerprint string `json:"fingerprint"`
+	// Error is a full error string of the top error
+	Error string `json:"error"`
+	// Stack contains layers of the error from the top one to the deepest one
+	Stack []LayerRecord `json:"stack"`
+	// Cause is a message of the original error
+	Cause string `json:"cause,omitempty"`
+	// Violations are field violations of InvalidArguments error, see Validation.
+	// They are not repeated in baggage of layers
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// LayerRecord is a serializable representation of single CustomError in the error stack
+type LayerRecord struct {
+	Message  string `json:"message"`
+	Type     string `json:"type"`
+	Level    string `json:"level"`
+	Severity string `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	// PublicMessage and PublicCode are shown to clients
+	PublicMessage string       `json:"publicMessage,omitempty"`
+	PublicCode    string       `json:"publicCode,omitempty"`
+	Path          string       `json:"path,omitempty"`
+	Baggage       ErrorBaggage `json:"baggage,omitempty"`
+}
+
+// NewErrorRecord create serializable representation of CustomError
+func NewErrorRecord(err CustomError) ErrorRecord {
+	stack := make([]CustomError, 0)
+	err.getStack(&stack)
+
+	record := ErrorRecord{
+		Fingerprint: Fingerprint(err),
+		Error:       err.Error(),
+		Stack:       make([]LayerRecord, 0, len(stack)),
+	}
+	for _, v := range stack {
+		record.Stack = append(record.Stack, LayerRecord{
+			Message:       v.GetMessage().String(),
+			Type:          v.GetType().String(),
+			Level:         v.GetLevel().String(),
+			Severity:      v.GetSeverity().String(),
+			Code:          v.GetCode().String(),
+			PublicMessage: v.GetPublicMessage().String(),
+			PublicCode:    v.GetPublicCode().String(),
+			Path:          v.GetPath().String(),
+			Baggage:       jsonBaggage(v.GetBaggage()),
+		})
+	}
+	if cause := Cause(err); cause != nil {
+		record.Cause = cause.Error()
+	}
+	record.Violations = jsonViolations(Violations(err))
+	return record
+}
+
+// MarshalJSON implements json.Marshaler interface, error is represented as ErrorRecord
+func (e *customErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(NewErrorRecord(e))
+}
+
+// jsonBaggage returns copy of baggage where values that can not be represented in JSON are replaced by their string value,
+// violations are omitted as they are stored in ErrorRecord.Violations
+func jsonBaggage(baggage ErrorBaggage) ErrorBaggage {
+	result := make(ErrorBaggage, len(baggage))
+	for k, v := range baggage {
+		if k == ViolationsBaggageKey {
+			continue
+		}
+		result[k] = jsonValue(v)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// jsonViolations returns copy of violations where rejected values that can not be represented in JSON
+// are replaced by their string value
+func jsonViolations(violations []Violation) []Violation {
+	if len(violations) == 0 {
+		return nil
+	}
+	result := make([]Violation, len(violations))
+	for k, v := range violations {
+		v.Value = jsonValue(v.Value)
+		result[k] = v
+	}
+	return result
+}
+
+// jsonValue returns value as is or its string value if it can not be represented in JSON, e.g. NaN or channel
+func jsonValue(value interface{}) interface{} {
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return value
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadUser() CustomError {
+	return newTyped(NotFound)
+}
+
+func newTyped(errType ErrorType) CustomError {
+	return errType.NewBase("user not found")
+}
+
+func TestFingerprint(t *testing.T) {
+	assertions := assert.New(t)
+
+	var fingerprints []string
+	for k := 0; k < 2; k++ {
+		fingerprints = append(fingerprints, Fingerprint(WrapF(loadUser(), "load user %d", k)))
+	}
+	assertions.Len(fingerprints[0], fingerprintLength)
+	assertions.Equal(fingerprints[0], fingerprints[1], "Check that message arguments do not change fingerprint")
+	assertions.NotEqual(fingerprints[0], Fingerprint(loadUser()), "Check that stack changes fingerprint")
+	assertions.NotEqual(Fingerprint(newTyped(NotFound)), Fingerprint(newTyped(InternalError)), "Check that type changes fingerprint")
+}
+
+func TestParseErrorType(t *testing.T) {
+	assertions := assert.New(t)
+
+	for _, v := range []ErrorType{DefaultType, NotFound, Unauthorized, ErrorType(42)} {
+		parsed, err := ParseErrorType(v.String())
+		assertions.NoError(err)
+		assertions.Equal(v, parsed)
+	}
+	parsed, err := ParseErrorType("3")
+	assertions.NoError(err)
+	assertions.Equal(InternalError, parsed)
+	_, err = ParseErrorType("Unknown")
+	assertions.Error(err)
+
+	level, err := ParseErrorLevel("datalevel")
+	assertions.NoError(err)
+	assertions.Equal(DataLevel, level)
+
+	severity, err := ParseErrorSeverity("ErrorSeverity(10)")
+	assertions.NoError(err)
+	assertions.Equal(ErrorSeverity(10), severity)
+}
+
+func TestCustomErr_MarshalJSON(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := Wrap(New(NotFound, DataLevel, ErrorBaggage{"id": 1, "fn": func() {}}, Warning, "user not found"), "load user")
+	data, marshalErr := json.Marshal(err)
+	assertions.NoError(marshalErr)
+
+	var record ErrorRecord
+	assertions.NoError(json.Unmarshal(data, &record))
+	assertions.Equal(Fingerprint(err), record.Fingerprint)
+	assertions.Equal("load user: user not found", record.Error)
+	assertions.Equal("user not found", record.Cause)
+	assertions.Len(record.Stack, 2)
+	assertions.Equal("NotFound", record.Stack[1].Type)
+	assertions.Equal("DataLevel", record.Stack[1].Level)
+	assertions.Equal("Warning", record.Stack[1].Severity)
+	assertions.Equal(float64(1), record.Stack[1].Baggage["id"])
+	assertions.IsType("", record.Stack[1].Baggage["fn"], "Check that not serializable values are stringified")
+	assertions.NotEmpty(record.Stack[0].Path)
+}
@@ -0,0 +1,175 @@
+package errors
+
+import "sync"
+
+// GRPCCode is a gRPC status code, values are equal to google.golang.org/grpc/codes values,
+// so it could be converted with codes.Code(errType.GRPCCode())
+type GRPCCode uint32
+
+// gRPC status codes
+const (
+	GRPCOK GRPCCode = iota
+	GRPCCanceled
+	GRPCUnknown
+	GRPCInvalidArgument
+	GRPCDeadlineExceeded
+	GRPCNotFound
+	GRPCAlreadyExists
+	GRPCPermissionDenied
+	GRPCResourceExhausted
+	GRPCFailedPrecondition
+	GRPCAborted
+	GRPCOutOfRange
+	GRPCUnimplemented
+	GRPCInternal
+	GRPCUnavailable
+	GRPCDataLoss
+	GRPCUnauthenticated
+)
+
+// TypeInfo describes standard attributes of error type
+type TypeInfo struct {
+	// HTTPStatus is HTTP response status code
+	HTTPStatus int
+	// GRPCCode is gRPC status code
+	GRPCCode GRPCCode
+	// Retryable is true if operation that returned error could be retried
+	Retryable bool
+	// Severity is a recommended default severity of error, see ApplyDefaultSeverities
+	Severity ErrorSeverity
+}
+
+// unknownTypeInfo is used for types without info and without parents with info
+var unknownTypeInfo = TypeInfo{HTTPStatus: 500, GRPCCode: GRPCUnknown, Severity: Critical}
+
+// typeInfos contain attributes of error types
+var typeInfos = struct {
+	sync.RWMutex
+	infos map[ErrorType]TypeInfo
+}{infos: map[ErrorType]TypeInfo{
+	NotFound:         {HTTPStatus: 404, GRPCCode: GRPCNotFound, Severity: Info},
+	InvalidArguments: {HTTPStatus: 400, GRPCCode: GRPCInvalidArgument, Severity: Info},
+	InternalError:    {HTTPStatus: 500, GRPCCode: GRPCInternal, Severity: Critical},
+	BadRequest:       {HTTPStatus: 400, GRPCCode: GRPCInvalidArgument, Severity: Info},
+	AccessDenied:     {HTTPStatus: 403, GRPCCode: GRPCPermissionDenied, Severity: Warning},
+	Unauthorized:     {HTTPStatus: 401, GRPCCode: GRPCUnauthenticated, Severity: Warning},
+
+	ClientError: {HTTPStatus: 400, GRPCCode: GRPCInvalidArgument, Severity: Info},
+	ServerError: {HTTPStatus: 500, GRPCCode: GRPCInternal, Severity: Critical},
+
+	Conflict:           {HTTPStatus: 409, GRPCCode: GRPCAborted, Severity: Warning},
+	AlreadyExists:      {HTTPStatus: 409, GRPCCode: GRPCAlreadyExists, Severity: Info},
+	PreconditionFailed: {HTTPStatus: 412, GRPCCode: GRPCFailedPrecondition, Severity: Info},
+	RateLimited:        {HTTPStatus: 429, GRPCCode: GRPCResourceExhausted, Retryable: true, Severity: Warning},
+	Canceled:           {HTTPStatus: 499, GRPCCode: GRPCCanceled, Severity: Info},
+
+	Timeout:        {HTTPStatus: 504, GRPCCode: GRPCDeadlineExceeded, Retryable: true, Severity: Warning},
+	Unavailable:    {HTTPStatus: 503, GRPCCode: GRPCUnavailable, Retryable: true, Severity: Warning},
+	NotImplemented: {HTTPStatus: 501, GRPCCode: GRPCUnimplemented, Severity: Warning},
+	DataLoss:       {HTTPStatus: 500, GRPCCode: GRPCDataLoss, Severity: Critical},
+}}
+
+// typeByHTTPStatus is used to restore error type from HTTP status code
+var typeByHTTPStatus = map[int]ErrorType{
+	400: BadRequest,
+	401: Unauthorized,
+	403: AccessDenied,
+	404: NotFound,
+	408: Timeout,
+	409: Conflict,
+	412: PreconditionFailed,
+	422: InvalidArguments,
+	429: RateLimited,
+	499: Canceled,
+	500: InternalError,
+	501: NotImplemented,
+	502: Unavailable,
+	503: Unavailable,
+	504: Timeout,
+}
+
+// typeByGRPCCode is used to restore error type from gRPC status code
+var typeByGRPCCode = map[GRPCCode]ErrorType{
+	GRPCCanceled:           Canceled,
+	GRPCUnknown:            InternalError,
+	GRPCInvalidArgument:    InvalidArguments,
+	GRPCDeadlineExceeded:   Timeout,
+	GRPCNotFound:           NotFound,
+	GRPCAlreadyExists:      AlreadyExists,
+	GRPCPermissionDenied:   AccessDenied,
+	GRPCResourceExhausted:  RateLimited,
+	GRPCFailedPrecondition: PreconditionFailed,
+	GRPCAborted:            Conflict,
+	GRPCOutOfRange:         InvalidArguments,
+	GRPCUnimplemented:      NotImplemented,
+	GRPCInternal:           InternalError,
+	GRPCUnavailable:        Unavailable,
+	GRPCDataLoss:           DataLoss,
+	GRPCUnauthenticated:    Unauthorized,
+}
+
+// SetTypeInfo sets attributes of error type, it could be used for own error types
+func SetTypeInfo(errType ErrorType, info TypeInfo) {
+	typeInfos.Lock()
+	defer typeInfos.Unlock()
+	typeInfos.infos[errType] = info
+}
+
+// Info returns attributes of error type. Types without own attributes use attributes of the closest parent type,
+// see SetTypeParent. Unknown types are described as 500 HTTP status and Unknown gRPC code
+func (i ErrorType) Info() TypeInfo {
+	for current, ok := i, true; ok; current, ok = current.Parent() {
+		typeInfos.RLock()
+		info, found := typeInfos.infos[current]
+		typeInfos.RUnlock()
+		if found {
+			return info
+		}
+	}
+	return unknownTypeInfo
+}
+
+// HTTPStatus returns HTTP response status code of error type
+func (i ErrorType) HTTPStatus() int {
+	return i.Info().HTTPStatus
+}
+
+// GRPCCode returns gRPC status code of error type
+func (i ErrorType) GRPCCode() GRPCCode {
+	return i.Info().GRPCCode
+}
+
+// Retryable returns true if operation that returned error of this type could be retried
+func (i ErrorType) Retryable() bool {
+	return i.Info().Retryable
+}
+
+// TypeFromHTTPStatus returns error type for HTTP status code, unknown 4xx codes are mapped to ClientError,
+// unknown 5xx codes are mapped to InternalError and other codes are mapped to DefaultType
+func TypeFromHTTPStatus(status int) ErrorType {
+	if errType, ok := typeByHTTPStatus[status]; ok {
+		return errType
+	}
+	switch {
+	case status >= 500:
+		return InternalError
+	case status >= 400:
+		return ClientError
+	}
+	return DefaultType
+}
+
+// TypeFromGRPCCode returns error type for gRPC status code, OK and unknown codes are mapped to DefaultType
+func TypeFromGRPCCode(code GRPCCode) ErrorType {
+	return typeByGRPCCode[code]
+}
+
+// ApplyDefaultSeverities sets up severity policies of all error types with info from the recommended severities,
+// see SetTypeSeverity
+func ApplyDefaultSeverities() {
+	typeInfos.RLock()
+	defer typeInfos.RUnlock()
+	for errType, info := range typeInfos.infos {
+		SetTypeSeverity(errType, info.Severity)
+	}
+}
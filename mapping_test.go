@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorType_Values(t *testing.T) {
+	assertions := assert.New(t)
+	// values of existing types must not be changed
+	for k, v := range []ErrorType{DefaultType, NotFound, InvalidArguments, InternalError, BadRequest, AccessDenied, Unauthorized} {
+		assertions.Equal(ErrorType(k), v)
+	}
+	assertions.Equal("DataLoss", DataLoss.String())
+	assertions.Equal("RateLimited", RateLimited.String())
+}
+
+func TestErrorType_Info(t *testing.T) {
+	assertions := assert.New(t)
+
+	for errType := NotFound; errType <= DataLoss; errType++ {
+		typeInfos.RLock()
+		info, ok := typeInfos.infos[errType]
+		typeInfos.RUnlock()
+		assertions.True(ok, "Check that %s has info", errType)
+		assertions.NotZero(info.HTTPStatus, "Check %s http status", errType)
+		assertions.NotEqual(GRPCOK, info.GRPCCode, "Check %s gRPC code", errType)
+		assertions.NotEqual(DefaultSeverity, info.Severity, "Check %s severity", errType)
+		assertions.True(errType.IsA(ClientError) || errType.IsA(ServerError), "Check %s category", errType)
+		assertions.Equal(info.HTTPStatus < 500, errType.IsA(ClientError), "Check %s category by http status", errType)
+	}
+
+	expected := map[ErrorType]TypeInfo{
+		NotFound:    {HTTPStatus: 404, GRPCCode: GRPCNotFound, Severity: Info},
+		Conflict:    {HTTPStatus: 409, GRPCCode: GRPCAborted, Severity: Warning},
+		Timeout:     {HTTPStatus: 504, GRPCCode: GRPCDeadlineExceeded, Retryable: true, Severity: Warning},
+		Unavailable: {HTTPStatus: 503, GRPCCode: GRPCUnavailable, Retryable: true, Severity: Warning},
+		RateLimited: {HTTPStatus: 429, GRPCCode: GRPCResourceExhausted, Retryable: true, Severity: Warning},
+		DefaultType: unknownTypeInfo,
+	}
+	for k, v := range expected {
+		assertions.Equal(v, k.Info(), "Check %s info", k)
+	}
+	assertions.Equal(503, Unavailable.HTTPStatus())
+	assertions.Equal(GRPCUnauthenticated, Unauthorized.GRPCCode())
+	assertions.True(Timeout.Retryable())
+	assertions.False(InvalidArguments.Retryable())
+
+	restoreTypeParents(t)
+	restoreTypeInfos(t)
+	ownType := ErrorType(200)
+	SetTypeParent(ownType, Unavailable)
+	assertions.Equal(Unavailable.Info(), ownType.Info(), "Check info of parent type")
+	SetTypeInfo(ownType, TypeInfo{HTTPStatus: 502, GRPCCode: GRPCUnavailable, Retryable: true})
+	assertions.Equal(502, ownType.HTTPStatus(), "Check own info")
+}
+
+// restoreTypeInfos restores attributes of error types after the test
+func restoreTypeInfos(t *testing.T) {
+	typeInfos.RLock()
+	infos := make(map[ErrorType]TypeInfo, len(typeInfos.infos))
+	for k, v := range typeInfos.infos {
+		infos[k] = v
+	}
+	typeInfos.RUnlock()
+	t.Cleanup(func() {
+		typeInfos.Lock()
+		typeInfos.infos = infos
+		typeInfos.Unlock()
+	})
+}
+
+func TestTypeFromHTTPStatus(t *testing.T) {
+	assertions := assert.New(t)
+
+	for _, v := range []ErrorType{NotFound, Unauthorized, AccessDenied, Conflict, PreconditionFailed, RateLimited, Canceled, Timeout, Unavailable, NotImplemented} {
+		assertions.Equal(v, TypeFromHTTPStatus(v.HTTPStatus()), "Check round trip of %s", v)
+	}
+	assertions.Equal(Timeout, TypeFromHTTPStatus(408))
+	assertions.Equal(InvalidArguments, TypeFromHTTPStatus(422))
+	assertions.Equal(ClientError, TypeFromHTTPStatus(418))
+	assertions.Equal(Unavailable, TypeFromHTTPStatus(502), "Check that bad gateway is retried as unavailable")
+	assertions.Equal(InternalError, TypeFromHTTPStatus(507))
+	assertions.Equal(DefaultType, TypeFromHTTPStatus(302))
+}
+
+func TestTypeFromGRPCCode(t *testing.T) {
+	assertions := assert.New(t)
+
+	for code := GRPCCanceled; code <= GRPCUnauthenticated; code++ {
+		assertions.NotEqual(DefaultType, TypeFromGRPCCode(code), "Check %d code", code)
+	}
+	for _, v := range []ErrorType{NotFound, InternalError, AccessDenied, Unauthorized, Conflict, AlreadyExists, PreconditionFailed, RateLimited, Canceled, Timeout, Unavailable, NotImplemented, DataLoss} {
+		assertions.Equal(v, TypeFromGRPCCode(v.GRPCCode()), "Check round trip of %s", v)
+	}
+	assertions.Equal(DefaultType, TypeFromGRPCCode(GRPCOK))
+}
+
+func TestApplyDefaultSeverities(t *testing.T) {
+	assertions := assert.New(t)
+	t.Cleanup(ResetPolicies)
+
+	ApplyDefaultSeverities()
+	assertions.Equal(Info, NotFound.NewBase("not found").GetSeverity())
+	assertions.Equal(Critical, InternalError.NewBase("internal").GetSeverity())
+	assertions.Equal(Warning, Timeout.NewBase("timeout").GetSeverity())
+}
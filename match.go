@@ -0,0 +1,213 @@
+package errors
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Matcher checks if single CustomError layer satisfies condition
+type Matcher func(err CustomError) bool
+
+// HasType matches errors with any of provided types or their descendant types, see SetTypeParent
+func HasType(types ...ErrorType) Matcher {
+	return func(err CustomError) bool {
+		for _, v := range types {
+			if err.GetType().IsA(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasExactType matches errors with any of provided types, descendant types are not matched
+func HasExactType(types ...ErrorType) Matcher {
+	return func(err CustomError) bool {
+		for _, v := range types {
+			if err.GetType() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasLevel matches errors with any of provided levels
+func HasLevel(levels ...ErrorLevel) Matcher {
+	return func(err CustomError) bool {
+		for _, v := range levels {
+			if err.GetLevel() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasSeverity matches errors with any of provided severities
+func HasSeverity(severities ...ErrorSeverity) Matcher {
+	return func(err CustomError) bool {
+		for _, v := range severities {
+			if err.GetSeverity() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasCode matches errors with any of provided sentinel codes
+func HasCode(codes ...ErrorCode) Matcher {
+	return func(err CustomError) bool {
+		for _, v := range codes {
+			if err.GetCode() == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SeverityAtLeast matches errors with severity that is greater or equal to provided one
+func SeverityAtLeast(severity ErrorSeverity) Matcher {
+	return func(err CustomError) bool {
+		return err.GetSeverity() >= severity
+	}
+}
+
+// MessageContains matches errors which message contains substring
+func MessageContains(substr string) Matcher {
+	return func(err CustomError) bool {
+		return strings.Contains(err.GetMessage().String(), substr)
+	}
+}
+
+// MessageMatches matches errors which message matches regular expression
+func MessageMatches(re *regexp.Regexp) Matcher {
+	return func(err CustomError) bool {
+		return re.MatchString(err.GetMessage().String())
+	}
+}
+
+// HasBaggageKey matches errors which baggage contains key
+func HasBaggageKey(key string) Matcher {
+	return func(err CustomError) bool {
+		_, ok := err.GetBaggage()[key]
+		return ok
+	}
+}
+
+// HasBaggageValue matches errors which baggage contains key with value, values are compared with reflect.DeepEqual
+func HasBaggageValue(key string, value interface{}) Matcher {
+	return func(err CustomError) bool {
+		v, ok := err.GetBaggage()[key]
+		return ok && reflect.DeepEqual(v, value)
+	}
+}
+
+// All matches errors that satisfy all matchers
+func All(matchers ...Matcher) Matcher {
+	return func(err CustomError) bool {
+		for _, m := range matchers {
+			if !m(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches errors that satisfy at least one matcher
+func Any(matchers ...Matcher) Matcher {
+	return func(err CustomError) bool {
+		for _, m := range matchers {
+			if m(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches errors that do not satisfy matcher
+func Not(matcher Matcher) Matcher {
+	return func(err CustomError) bool {
+		return !matcher(err)
+	}
+}
+
+// FindInStack returns the first CustomError layer of error chain that satisfies matcher.
+// Chain is traversed in depth-first order including errors that are not CustomError,
+// e.g. errors wrapped by fmt.Errorf with %w and errors joined by errors.Join
+func FindInStack(err error, matcher Matcher) (CustomError, bool) {
+	var result CustomError
+	walk(err, func(customErr CustomError) bool {
+		if matcher(customErr) {
+			result = customErr
+			return false
+		}
+		return true
+	})
+	return result, result != nil
+}
+
+// FindAllInStack returns all CustomError layers of error chain that satisfy matcher, see FindInStack
+func FindAllInStack(err error, matcher Matcher) []CustomError {
+	var result []CustomError
+	walk(err, func(customErr CustomError) bool {
+		if matcher(customErr) {
+			result = append(result, customErr)
+		}
+		return true
+	})
+	return result
+}
+
+// MatchInStack returns true if any CustomError layer of error chain satisfies matcher, see FindInStack
+func MatchInStack(err error, matcher Matcher) bool {
+	_, ok := FindInStack(err, matcher)
+	return ok
+}
+
+// Matching returns target for errors.Is that is matched by any CustomError layer satisfying all matchers
+//
+//	errors.Is(err, cErrors.Matching(cErrors.HasType(cErrors.NotFound), cErrors.HasLevel(cErrors.DataLevel)))
+func Matching(matchers ...Matcher) error {
+	return &matchTarget{matcher: All(matchers...)}
+}
+
+// matchTarget is errors.Is target created by Matching function
+type matchTarget struct {
+	matcher Matcher
+}
+
+func (m *matchTarget) Error() string {
+	return "error matcher"
+}
+
+// walk calls fn for every CustomError layer of error chain until fn returns false,
+// returns false if walking has been stopped
+func walk(err error, fn func(customErr CustomError) bool) bool {
+	for err != nil {
+		switch v := err.(type) {
+		case CustomError:
+			if !fn(v) {
+				return false
+			}
+			err = v.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range v.Unwrap() {
+				if !walk(e, fn) {
+					return false
+				}
+			}
+			return true
+		case interface{ Unwrap() error }:
+			err = v.Unwrap()
+		default:
+			return true
+		}
+	}
+	return true
+}
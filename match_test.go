@@ -0,0 +1,80 @@
+package errors
+
+import (
+	errs "errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// joinedErrs is analogous to the error returned by errors.Join
+type joinedErrs []error
+
+func (j joinedErrs) Error() string {
+	return fmt.Sprint([]error(j))
+}
+
+func (j joinedErrs) Unwrap() []error {
+	return j
+}
+
+func referenceChain() error {
+	data := New(NotFound, DataLevel, ErrorBaggage{"userID": 42}, Info, "user not found")
+	useCase := WrapF(data, "load user %d", 42).SetLevel(UseCaseLevel)
+	native := fmt.Errorf("native wrapper: %w", useCase)
+	internal := InternalError.New(ContainerLevel, ErrorBaggage{"query": "select"}, Critical, "query failed")
+	return Wrap(joinedErrs{native, internal}, "controller").SetLevel(ControllerLevel)
+}
+
+func TestFindInStack(t *testing.T) {
+	assertions := assert.New(t)
+	err := referenceChain()
+
+	found, ok := FindInStack(err, HasType(NotFound))
+	assertions.True(ok)
+	assertions.Equal(ErrorMessage("load user 42"), found.GetMessage(), "Check that the first matched layer is returned")
+
+	found, ok = FindInStack(err, All(HasType(NotFound), HasLevel(DataLevel)))
+	assertions.True(ok, "Check traversing of fmt.Errorf wrapper")
+	assertions.Equal(ErrorMessage("user not found"), found.GetMessage())
+
+	found, ok = FindInStack(err, HasBaggageKey("query"))
+	assertions.True(ok, "Check traversing of joined errors")
+	assertions.Equal(InternalError, found.GetType())
+
+	_, ok = FindInStack(err, HasType(Unauthorized))
+	assertions.False(ok)
+	_, ok = FindInStack(errNativeReference, HasType(DefaultType))
+	assertions.False(ok, "Check native error without custom layers")
+}
+
+func TestFindAllInStack(t *testing.T) {
+	assertions := assert.New(t)
+	err := referenceChain()
+
+	assertions.Len(FindAllInStack(err, HasType(NotFound)), 2)
+	assertions.Len(FindAllInStack(err, Any(HasType(InternalError), HasLevel(ControllerLevel))), 2)
+	assertions.Len(FindAllInStack(err, Not(HasType(NotFound))), 2)
+	assertions.Len(FindAllInStack(err, SeverityAtLeast(Info)), 3)
+	assertions.Len(FindAllInStack(err, HasSeverity(Critical)), 1)
+	assertions.Len(FindAllInStack(err, MessageContains("user")), 2)
+	assertions.Len(FindAllInStack(err, MessageMatches(regexp.MustCompile(`^load user \d+$`))), 1)
+	assertions.Len(FindAllInStack(err, HasBaggageValue("userID", 42)), 1)
+	assertions.Empty(FindAllInStack(err, HasBaggageValue("userID", "42")))
+	assertions.Empty(FindAllInStack(nil, HasType(NotFound)))
+
+	assertions.True(MatchInStack(err, HasLevel(UseCaseLevel)))
+	assertions.False(MatchInStack(err, HasLevel(TransportLevel)))
+}
+
+func TestMatching(t *testing.T) {
+	assertions := assert.New(t)
+	err := referenceChain()
+
+	assertions.True(errs.Is(err, Matching(HasType(NotFound), HasLevel(DataLevel))))
+	assertions.True(errs.Is(err, Matching(HasBaggageValue("query", "select"))))
+	assertions.False(errs.Is(err, Matching(HasType(NotFound), HasLevel(ContainerLevel))))
+	assertions.True(errs.Is(err, NotFound.NewBase("")), "Check that type comparison is kept")
+}
@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"errors"
+
+	errs "github.com/pkg/errors"
+)
+
+// Option sets up attribute of error created by Make or WrapWith
+type Option func(s *settings)
+
+// settings contains error attributes collected from options
+type settings struct {
+	errType     ErrorType
+	typeSet     bool
+	level       ErrorLevel
+	levelSet    bool
+	severity    ErrorSeverity
+	severitySet bool
+	baggage     ErrorBaggage
+	cause       error
+	code        ErrorCode
+	// public message and code of error
+	publicMessage ErrorMessage
+	publicCode    ErrorCode
+	// count of frames skipped in the stack of error
+	callerSkip int
+}
+
+// WithType sets error type
+func WithType(errType ErrorType) Option {
+	return func(s *settings) {
+		s.errType = errType
+		s.typeSet = true
+	}
+}
+
+// WithLevel sets error data level
+func WithLevel(level ErrorLevel) Option {
+	return func(s *settings) {
+		s.level = level
+		s.levelSet = true
+	}
+}
+
+// WithSeverity sets error severity
+func WithSeverity(severity ErrorSeverity) Option {
+	return func(s *settings) {
+		s.severity = severity
+		s.severitySet = true
+	}
+}
+
+// WithBaggage adds fields into error baggage, could be used several times
+func WithBaggage(baggage ErrorBaggage) Option {
+	return func(s *settings) {
+		for k, v := range baggage {
+			s.baggage[k] = v
+		}
+	}
+}
+
+// WithCode sets sentinel code of error, so created error is matched by errors.Is with the sentinel of this code
+func WithCode(code ErrorCode) Option {
+	return func(s *settings) {
+		s.code = code
+	}
+}
+
+// WithCause sets error that will be wrapped by created error
+func WithCause(err error) Option {
+	return func(s *settings) {
+		s.cause = err
+	}
+}
+
+// WithCallerSkip skips frames of functions that create errors on behalf of their callers, e.g. helpers
+// and generated constructors, so path of created error is the place where the helper is called
+//
+//	func errNotFound(id int) errors.CustomError {
+//		return errors.Make("user not found", errors.WithType(errors.NotFound), errors.WithCallerSkip(1))
+//	}
+func WithCallerSkip(skip int) Option {
+	return func(s *settings) {
+		s.callerSkip = skip
+	}
+}
+
+// Options combines several options into one, it allows to create reusable presets:
+//
+//	dbLayer := errors.Options(errors.WithLevel(errors.DataLevel), errors.WithSeverity(errors.Warning))
+//	err := errors.Make("user not found", dbLayer, errors.WithType(errors.NotFound))
+//
+// Options are applied in order, so options passed after preset override its values
+func Options(opts ...Option) Option {
+	return func(s *settings) {
+		for _, opt := range opts {
+			opt(s)
+		}
+	}
+}
+
+// newSettings applies options
+func newSettings(opts []Option) *settings {
+	s := &settings{baggage: make(ErrorBaggage)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// inherit copies attributes that are not set explicitly from the cause if it is CustomError
+func (s *settings) inherit() {
+	if customErr, ok := s.cause.(CustomError); ok {
+		if !s.typeSet {
+			s.errType = customErr.GetType()
+		}
+		if !s.levelSet {
+			s.level = customErr.GetLevel()
+		}
+		if !s.severitySet {
+			s.severity = customErr.GetSeverity()
+		}
+	}
+}
+
+// setCode sets explicit sentinel code and public attributes of error, code inherited from the wrapped error is kept otherwise
+func (s *settings) setCode(err *customErr) CustomError {
+	if s.code != "" {
+		err.code = s.code
+	}
+	err.publicMessage = s.publicMessage
+	err.publicCode = s.publicCode
+	return err
+}
+
+// Make create custom error with message and attributes set up by options,
+// not specified attributes have default values
+//
+//	err := errors.Make("user not found", errors.WithType(errors.NotFound), errors.WithLevel(errors.DataLevel))
+//
+// If cause is passed with WithCause option then the cause is wrapped analogous to WrapWith
+func Make(message ErrorMessage, opts ...Option) CustomError {
+	s := newSettings(opts)
+	s.inherit()
+	if s.callerSkip > 0 {
+		// the stack starts from the caller of Make, so skipped frames are counted from it
+		var wrappedErr error = &transparent{err: errors.New(message.String())}
+		if s.cause != nil {
+			wrappedErr = errs.WithMessage(s.cause, message.String())
+		}
+		wrappedErr = &stackErr{err: wrappedErr, stack: callers(s.callerSkip)}
+		return s.setCode(newCustomErr(s.errType, s.baggage, s.level, s.severity, wrappedErr))
+	}
+	if s.cause != nil {
+		return s.setCode(newCustomErr(s.errType, s.baggage, s.level, s.severity, errs.Wrap(s.cause, message.String())))
+	}
+	return s.setCode(newCustomErr(s.errType, s.baggage, s.level, s.severity, errs.New(message.String())))
+}
+
+// WrapWith wraps error with message and attributes set up by options. If wrapped error implements CustomError
+// interface than not specified type, level and severity are copied from it, otherwise not specified type
+// could be set up by translators, analogous to Wrap
+func WrapWith(err error, message ErrorMessage, opts ...Option) CustomError {
+	s := newSettings(opts)
+	s.cause = err
+	s.inherit()
+	if _, ok := err.(CustomError); !ok {
+		if errType, baggage, ok := translateOnWrap(err); ok {
+			if !s.typeSet {
+				s.errType = errType
+			}
+			for k, v := range baggage {
+				if _, exist := s.baggage[k]; !exist {
+					s.baggage[k] = v
+				}
+			}
+		}
+	}
+	return s.setCode(newCustomErr(s.errType, s.baggage, s.level, s.severity, errs.Wrap(err, message.String())))
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	errs "errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := Make(ErrorMessage(referenceErrorText))
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Equal(DefaultType, err.GetType())
+	assertions.Equal(DefaultLevel, err.GetLevel())
+	assertions.Equal(DefaultSeverity, err.GetSeverity())
+	assertions.Equal(ErrorBaggage{}, err.GetBaggage())
+	assertions.Equal(referenceErrorText, err.GetMessage().String())
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check err path")
+
+	err = Make(ErrorMessage(referenceErrorText),
+		WithType(referenceErrType), WithLevel(referenceLevel), WithSeverity(referenceSeverity),
+		WithBaggage(ErrorBaggage{"key1": "value1"}), WithBaggage(ErrorBaggage{"key2": "value2"}),
+	)
+	assertions.Equal(referenceErrType, err.GetType())
+	assertions.Equal(referenceLevel, err.GetLevel())
+	assertions.Equal(referenceSeverity, err.GetSeverity())
+	assertions.Equal(ErrorBaggage{"key1": "value1", "key2": "value2"}, err.GetBaggage())
+
+	err = Make("Wrapped", WithCause(referenceError()), WithSeverity(Critical))
+	assertions.Equal(referenceErrType, err.GetType(), "Check that type is copied from cause")
+	assertions.Equal(referenceLevel, err.GetLevel(), "Check that level is copied from cause")
+	assertions.Equal(Critical, err.GetSeverity(), "Check that explicit severity overrides cause one")
+	assertions.Equal(fmt.Sprintf("Wrapped: %s", referenceErrorText), err.Error())
+}
+
+func TestWrapWith(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := WrapWith(errNativeReference, ErrorMessage(referenceErrorText))
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Equal(DefaultType, err.GetType())
+	assertions.Equal(DefaultLevel, err.GetLevel())
+	assertions.Equal(DefaultSeverity, err.GetSeverity())
+	assertions.Equal(errNativeReference, err.Unwrap())
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check err path")
+
+	err = WrapWith(referenceError(), ErrorMessage(referenceErrorText), WithType(BadRequest))
+	assertions.Equal(BadRequest, err.GetType())
+	assertions.Equal(referenceLevel, err.GetLevel())
+	assertions.Equal(referenceSeverity, err.GetSeverity())
+	assertions.Equal(ErrorBaggage{}, err.GetBaggage())
+}
+
+func TestOptions(t *testing.T) {
+	assertions := assert.New(t)
+	dbLayer := Options(WithLevel(DataLevel), WithSeverity(Warning))
+
+	err := Make("user not found", dbLayer, WithType(NotFound))
+	assertions.Equal(NotFound, err.GetType())
+	assertions.Equal(DataLevel, err.GetLevel())
+	assertions.Equal(Warning, err.GetSeverity())
+
+	err = WrapWith(errNativeReference, "query failed", dbLayer, WithSeverity(Critical))
+	assertions.Equal(DataLevel, err.GetLevel())
+	assertions.Equal(Critical, err.GetSeverity(), "Check that options after preset override it")
+}
+
+// makeOnBehalf creates error on behalf of its caller
+func makeOnBehalf(opts ...Option) CustomError {
+	return Make(ErrorMessage(referenceErrorText), append(opts, WithCallerSkip(1))...)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := makeOnBehalf()
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that helper frame is skipped")
+	assertions.Equal(referenceErrorText, err.Error())
+	assertions.Equal(referenceErrorText, err.GetMessage().String())
+
+	err = makeOnBehalf(WithCause(errNativeReference))
+	_, fn, line, _ = runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that helper frame is skipped")
+	assertions.Equal(fmt.Sprintf("%s: %s", referenceErrorText, errNativeReference), err.Error())
+	assertions.Equal(referenceErrorText, err.GetMessage().String())
+	assertions.True(errs.Is(err, errNativeReference))
+}
+
+func TestWithCode(t *testing.T) {
+	assertions := assert.New(t)
+	sentinel := NewSentinel("test.options.code", Unavailable, "unavailable")
+
+	err := Make("breaker is open", WithCode("test.options.code"), WithCause(errNativeReference))
+	assertions.Equal(ErrorCode("test.options.code"), err.GetCode())
+	assertions.True(errs.Is(err, sentinel))
+	assertions.True(errs.Is(err, errNativeReference))
+
+	err = WrapWith(sentinel, "wrapped")
+	assertions.Equal(ErrorCode("test.options.code"), err.GetCode(), "Check that inherited code is kept")
+}
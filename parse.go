@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseErrorType returns ErrorType by its name (e.g. "NotFound" or name set by SetTypeName),
+// string representation of not named type (e.g. "ErrorType(42)") or plain integer code
+func ParseErrorType(s string) (ErrorType, error) {
+	for errType, name := range errorTypeNames {
+		if strings.EqualFold(name, s) {
+			return errType, nil
+		}
+	}
+	typeNames.RLock()
+	defer typeNames.RUnlock()
+	for errType, name := range typeNames.names {
+		if strings.EqualFold(name, s) {
+			return errType, nil
+		}
+	}
+	code, err := parseCode(s, "ErrorType")
+	if err != nil || code < 0 {
+		return DefaultType, fmt.Errorf("unknown error type %q", s)
+	}
+	return ErrorType(code), nil
+}
+
+// ParseErrorLevel returns ErrorLevel by its name, string representation or plain integer code
+func ParseErrorLevel(s string) (ErrorLevel, error) {
+	for level, name := range errorLevelNames {
+		if strings.EqualFold(name, s) {
+			return level, nil
+		}
+	}
+	code, err := parseCode(s, "ErrorLevel")
+	if err != nil {
+		return DefaultLevel, fmt.Errorf("unknown error level %q", s)
+	}
+	return ErrorLevel(code), nil
+}
+
+// ParseErrorSeverity returns ErrorSeverity by its name, string representation or plain integer code
+func ParseErrorSeverity(s string) (ErrorSeverity, error) {
+	for severity, name := range errorSeverityNames {
+		if strings.EqualFold(name, s) {
+			return severity, nil
+		}
+	}
+	code, err := parseCode(s, "ErrorSeverity")
+	if err != nil {
+		return DefaultSeverity, fmt.Errorf("unknown error severity %q", s)
+	}
+	return ErrorSeverity(code), nil
+}
+
+// parseCode parses "Name(42)" or "42" string into integer code
+func parseCode(s, name string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, name+"(") && strings.HasSuffix(s, ")") {
+		s = s[len(name)+1 : len(s)-1]
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+// PluralForm is a plural category of CLDR plural rules
+type PluralForm string
+
+// Plural forms
+const (
+	PluralZero  PluralForm = "zero"
+	PluralOne   PluralForm = "one"
+	PluralTwo   PluralForm = "two"
+	PluralFew   PluralForm = "few"
+	PluralMany  PluralForm = "many"
+	PluralOther PluralForm = "other"
+)
+
+// PluralRule returns plural form of integer count
+type PluralRule func(n int64) PluralForm
+
+// pluralRules contain plural rules by language, languages without rule use PluralOther for all counts
+var pluralRules = struct {
+	sync.RWMutex
+	byLanguage map[string]PluralRule
+}{byLanguage: map[string]PluralRule{
+	"en": oneOther,
+	"de": oneOther,
+	"es": oneOther,
+	"it": oneOther,
+	"nl": oneOther,
+	"pt": oneOther,
+	"fr": func(n int64) PluralForm {
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	},
+	"ru": eastSlavic,
+	"uk": eastSlavic,
+	"be": eastSlavic,
+	"pl": func(n int64) PluralForm {
+		switch {
+		case n == 1:
+			return PluralOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	},
+	"ja": otherOnly,
+	"ko": otherOnly,
+	"zh": otherOnly,
+}}
+
+// SetPluralRule sets plural rule of language, e.g. "en"
+func SetPluralRule(language string, rule PluralRule) {
+	pluralRules.Lock()
+	defer pluralRules.Unlock()
+	pluralRules.byLanguage[strings.ToLower(language)] = rule
+}
+
+// Plural returns plural form of count in locale, rule of the locale language is used for regional locales
+func Plural(locale string, n int64) PluralForm {
+	pluralRules.RLock()
+	defer pluralRules.RUnlock()
+	for _, candidate := range localeChain(locale) {
+		if rule, ok := pluralRules.byLanguage[candidate]; ok {
+			return rule(n)
+		}
+	}
+	return PluralOther
+}
+
+func oneOther(n int64) PluralForm {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func eastSlavic(n int64) PluralForm {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return PluralOne
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func otherOnly(int64) PluralForm {
+	return PluralOther
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// policies contain default attributes that are applied to created errors instead of DefaultSeverity and DefaultLevel.
+// They are empty by default and must be set up explicitly, e.g. with ApplyDefaultSeverities
+var policies = struct {
+	sync.RWMutex
+	severities map[ErrorType]ErrorSeverity
+	levels     []levelRule
+}{severities: make(map[ErrorType]ErrorSeverity)}
+
+// levelRule sets up default error level for packages that match the pattern
+type levelRule struct {
+	pattern string
+	re      *regexp.Regexp
+	level   ErrorLevel
+}
+
+// SetTypeSeverity sets default severity for errors of specified type.
+// It is applied by all constructors when severity is not specified or DefaultSeverity is passed
+func SetTypeSeverity(errType ErrorType, severity ErrorSeverity) {
+	policies.Lock()
+	defer policies.Unlock()
+	policies.severities[errType] = severity
+}
+
+// SetPackageLevel sets default level for errors created in packages that match the pattern.
+// Pattern is a package path where * matches any sequence of symbols including "/", pattern also matches
+// subpackages if it ends with "/*", e.g. "*/repository/*" matches "github.com/acme/shop/repository" and
+// "github.com/acme/shop/repository/postgres". Rules are checked in order of setting, the first matched rule is applied.
+// Level is applied by all constructors when level is not specified or DefaultLevel is passed,
+// package is determined by the place of error creation (see GetPath)
+func SetPackageLevel(pattern string, level ErrorLevel) {
+	re := regexp.MustCompile("^" + strings.Replace(regexp.QuoteMeta(pattern), `\*`, ".*", -1) + "$")
+
+	policies.Lock()
+	defer policies.Unlock()
+	for k, v := range policies.levels {
+		if v.pattern == pattern {
+			policies.levels[k].level = level
+			return
+		}
+	}
+	policies.levels = append(policies.levels, levelRule{pattern: pattern, re: re, level: level})
+}
+
+// ResetPolicies removes all severity and level policies
+func ResetPolicies() {
+	policies.Lock()
+	defer policies.Unlock()
+	policies.severities = make(map[ErrorType]ErrorSeverity)
+	policies.levels = nil
+}
+
+// TypeSeverity returns default severity of error type, DefaultSeverity is returned if policy is not set
+func TypeSeverity(errType ErrorType) ErrorSeverity {
+	policies.RLock()
+	defer policies.RUnlock()
+	return policies.severities[errType]
+}
+
+// PackageLevel returns default level of errors created in package, DefaultLevel is returned if there is no matched rule
+func PackageLevel(pkgPath string) ErrorLevel {
+	policies.RLock()
+	defer policies.RUnlock()
+	for _, v := range policies.levels {
+		if v.re.MatchString(pkgPath) || v.re.MatchString(pkgPath+"/") {
+			return v.level
+		}
+	}
+	return DefaultLevel
+}
+
+// applyPolicies replaces default severity and level of error with values from policies
+func (e *customErr) applyPolicies() {
+	if e.severity == DefaultSeverity {
+		e.severity = TypeSeverity(e.errType)
+	}
+	if e.level == DefaultLevel && hasLevelRules() {
+		if pkgPath := e.packagePath(); pkgPath != "" {
+			e.level = PackageLevel(pkgPath)
+		}
+	}
+}
+
+func hasLevelRules() bool {
+	policies.RLock()
+	defer policies.RUnlock()
+	return len(policies.levels) > 0
+}
+
+// packagePath returns package path of the function where error has been created
+func (e *customErr) packagePath() string {
+	err, ok := e.wrappedErr.(stackTracer)
+	if !ok {
+		return ""
+	}
+	st := err.StackTrace()
+	if len(st) == 0 {
+		return ""
+	}
+	frame := st[0]
+	if len(st) > callerSkip {
+		frame = st[callerSkip]
+	}
+	fn := runtime.FuncForPC(uintptr(frame) - 1)
+	if fn == nil {
+		return ""
+	}
+	return funcPackage(fn.Name())
+}
+
+// funcPackage returns package path from the full function name, e.g. "github.com/acme/shop/repository.(*Repo).Get"
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
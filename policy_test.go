@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTypeSeverity(t *testing.T) {
+	assertions := assert.New(t)
+	t.Cleanup(ResetPolicies)
+
+	SetTypeSeverity(NotFound, Info)
+	SetTypeSeverity(InternalError, Critical)
+
+	assertions.Equal(Info, NotFound.NewBase("not found").GetSeverity())
+	assertions.Equal(Info, New(NotFound, DataLevel, nil, DefaultSeverity, "not found").GetSeverity(), "Check that default value is replaced")
+	assertions.Equal(Warning, New(NotFound, DataLevel, nil, Warning, "not found").GetSeverity(), "Check that explicit value is kept")
+	assertions.Equal(Critical, InternalError.Wrap(errNativeReference, "internal").GetSeverity())
+	assertions.Equal(Critical, Make("internal", WithType(InternalError)).GetSeverity())
+	assertions.Equal(DefaultSeverity, NewBase("default").GetSeverity(), "Check type without policy")
+	assertions.Equal(Info, Wrap(NotFound.NewBase("not found"), "wrapped").GetSeverity(), "Check that severity is copied while wrapping")
+	assertions.Equal(Critical, TypeSeverity(InternalError))
+
+	ResetPolicies()
+	assertions.Equal(DefaultSeverity, NotFound.NewBase("not found").GetSeverity(), "Check policies reset")
+}
+
+func TestSetPackageLevel(t *testing.T) {
+	assertions := assert.New(t)
+	t.Cleanup(ResetPolicies)
+
+	SetPackageLevel("*/repository/*", DataLevel)
+	SetPackageLevel("github.com/acme/*", ControllerLevel)
+
+	assertions.Equal(DataLevel, PackageLevel("github.com/acme/shop/repository"))
+	assertions.Equal(DataLevel, PackageLevel("github.com/acme/shop/repository/postgres"))
+	assertions.Equal(ControllerLevel, PackageLevel("github.com/acme/shop/repositoryx"))
+	assertions.Equal(DefaultLevel, PackageLevel("github.com/other/shop"))
+
+	SetPackageLevel("*/go-custom-errors", UseCaseLevel)
+	assertions.Equal(UseCaseLevel, NewBase("created in this package").GetLevel())
+	assertions.Equal(UseCaseLevel, Wrap(errNativeReference, "wrapped in this package").GetLevel())
+	assertions.Equal(UseCaseLevel, NotFound.NewBaseF("%s", "typed").GetLevel())
+	assertions.Equal(TransportLevel, Make("explicit", WithLevel(TransportLevel)).GetLevel(), "Check that explicit value is kept")
+
+	SetPackageLevel("*/go-custom-errors", ContainerLevel)
+	assertions.Equal(ContainerLevel, NewBase("created in this package").GetLevel(), "Check that rule is replaced")
+}
+
+func Test_funcPackage(t *testing.T) {
+	assertions := assert.New(t)
+	assertions.Equal("github.com/acme/shop/repository", funcPackage("github.com/acme/shop/repository.(*Repo).Get"))
+	assertions.Equal("github.com/acme/shop/repository", funcPackage("github.com/acme/shop/repository.Get.func1"))
+	assertions.Equal("main", funcPackage("main.main"))
+}
@@ -0,0 +1,145 @@
+package errors
+
+import (
+	"sync"
+)
+
+// Exposure describes which error details could be shown to clients
+type Exposure int
+
+const (
+	// ExposePublic allows only public messages and codes
+	ExposePublic Exposure = iota
+	// ExposeInternal allows internal messages and error stack, it should be used only for trusted clients
+	// (e.g. other services or development environment)
+	ExposeInternal
+)
+
+// defaultPublicMessage is used for types without public message
+const defaultPublicMessage = ErrorMessage("Something went wrong")
+
+// typePublicMessages contain generic public messages of error types
+var typePublicMessages = struct {
+	sync.RWMutex
+	messages map[ErrorType]ErrorMessage
+}{messages: map[ErrorType]ErrorMessage{
+	NotFound:           "Resource not found",
+	InvalidArguments:   "Invalid arguments",
+	InternalError:      "Internal error",
+	BadRequest:         "Bad request",
+	AccessDenied:       "Access denied",
+	Unauthorized:       "Unauthorized",
+	ClientError:        "Bad request",
+	ServerError:        "Internal error",
+	Conflict:           "Conflict",
+	AlreadyExists:      "Resource already exists",
+	PreconditionFailed: "Precondition failed",
+	RateLimited:        "Too many requests",
+	Canceled:           "Request canceled",
+	Timeout:            "Request timed out",
+	Unavailable:        "Service unavailable",
+	NotImplemented:     "Not implemented",
+	DataLoss:           "Internal error",
+}}
+
+// WithPublicMessage sets message that could be shown to clients
+func WithPublicMessage(message ErrorMessage) Option {
+	return func(s *settings) {
+		s.publicMessage = message
+	}
+}
+
+// WithPublicCode sets error code that could be shown to clients
+func WithPublicCode(code ErrorCode) Option {
+	return func(s *settings) {
+		s.publicCode = code
+	}
+}
+
+// SetTypePublicMessage sets generic public message of error type, it is used when there is no public message in error chain
+func SetTypePublicMessage(errType ErrorType, message ErrorMessage) {
+	typePublicMessages.Lock()
+	defer typePublicMessages.Unlock()
+	typePublicMessages.messages[errType] = message
+}
+
+// PublicMessage returns generic public message of error type or of the closest parent type
+func (i ErrorType) PublicMessage() ErrorMessage {
+	typePublicMessages.RLock()
+	defer typePublicMessages.RUnlock()
+	for current, ok := i, true; ok; current, ok = current.Parent() {
+		if message, found := typePublicMessages.messages[current]; found {
+			return message
+		}
+	}
+	return defaultPublicMessage
+}
+
+// PublicMessage returns message of error that could be shown to clients: the outermost public message
+// in the error chain wins, generic message of the top error type is used if there are no public messages
+func PublicMessage(err error) ErrorMessage {
+	if layer, ok := FindInStack(err, func(e CustomError) bool { return e.GetPublicMessage() != "" }); ok {
+		return layer.GetPublicMessage()
+	}
+	return topType(err).PublicMessage()
+}
+
+// PublicCode returns code of error that could be shown to clients: the outermost public code in the error chain wins,
+// then the outermost sentinel code is used, the name of the top error type is used otherwise
+func PublicCode(err error) ErrorCode {
+	if layer, ok := FindInStack(err, func(e CustomError) bool { return e.GetPublicCode() != "" }); ok {
+		return layer.GetPublicCode()
+	}
+	if layer, ok := FindInStack(err, func(e CustomError) bool { return e.GetCode() != "" }); ok {
+		return layer.GetCode()
+	}
+	return ErrorCode(topType(err).String())
+}
+
+// ExternalMessage returns message of error according to exposure: public message or the full error message
+func ExternalMessage(err error, exposure Exposure) string {
+	if exposure == ExposeInternal {
+		return err.Error()
+	}
+	return PublicMessage(err).String()
+}
+
+// GRPCStatus returns gRPC status code and message of error according to exposure,
+// they could be used with status.New(codes.Code(code), message)
+func GRPCStatus(err error, exposure Exposure) (GRPCCode, string) {
+	return topType(err).GRPCCode(), ExternalMessage(err, exposure)
+}
+
+// PublicError is representation of error for clients, e.g. in JSON responses
+type PublicError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Type    string    `json:"type"`
+	// Violations are field violations of InvalidArguments error
+	Violations []Violation `json:"violations,omitempty"`
+	// Internal is full error representation, it is set only for ExposeInternal exposure
+	Internal *ErrorRecord `json:"internal,omitempty"`
+}
+
+// NewPublicError create representation of error for clients according to exposure
+func NewPublicError(err error, exposure Exposure) PublicError {
+	result := PublicError{
+		Code:       PublicCode(err),
+		Message:    ExternalMessage(err, exposure),
+		Type:       topType(err).String(),
+		Violations: jsonViolations(Violations(err)),
+	}
+	if customErr, ok := FindInStack(err, func(CustomError) bool { return true }); ok && exposure == ExposeInternal {
+		record := NewErrorRecord(customErr)
+		result.Internal = &record
+	}
+	return result
+}
+
+// topType returns type of the first CustomError in the error chain, InternalError is used for other errors
+func topType(err error) ErrorType {
+	if layer, ok := FindInStack(err, func(CustomError) bool { return true }); ok {
+		return layer.GetType()
+	}
+	return InternalError
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicMessage(t *testing.T) {
+	assertions := assert.New(t)
+
+	base := Make("select from users", WithType(NotFound), WithPublicMessage("User not found"), WithPublicCode("user.not_found"))
+	err := WrapWith(base, "load profile", WithPublicMessage("Profile is unavailable"))
+
+	assertions.Equal(ErrorMessage("Profile is unavailable"), PublicMessage(err), "Check outermost public message wins")
+	assertions.Equal(ErrorCode("user.not_found"), PublicCode(err))
+	assertions.Equal(ErrorMessage("User not found"), PublicMessage(fmt.Errorf("native: %w", base)))
+
+	plain := Wrap(NotFound.NewBase("select from users"), "load profile")
+	assertions.Equal(ErrorMessage("Resource not found"), PublicMessage(plain), "Check generic message of type")
+	assertions.Equal(ErrorCode("NotFound"), PublicCode(plain))
+	assertions.Equal(ErrorCode("test.user.not_found"), PublicCode(Wrap(errUserNotFound, "load")), "Check sentinel code")
+	assertions.Equal(ErrorMessage("Internal error"), PublicMessage(fmt.Errorf("native")))
+
+	plain.SetPublicMessage("Nothing here").SetPublicCode("nothing")
+	assertions.Equal(ErrorMessage("Nothing here"), PublicMessage(plain))
+	assertions.Equal(ErrorCode("nothing"), PublicCode(plain))
+}
+
+func TestTypePublicMessage(t *testing.T) {
+	assertions := assert.New(t)
+	const paymentRequired = ErrorType(1301)
+	SetTypeParent(paymentRequired, ClientError)
+
+	assertions.Equal(ErrorMessage("Bad request"), paymentRequired.PublicMessage(), "Check message of parent type")
+	SetTypePublicMessage(paymentRequired, "Payment required")
+	assertions.Equal(ErrorMessage("Payment required"), paymentRequired.PublicMessage())
+	assertions.Equal(defaultPublicMessage, ErrorType(1302).PublicMessage())
+}
+
+func TestExposure(t *testing.T) {
+	assertions := assert.New(t)
+	err := WrapWith(Unavailable.NewBase("dial tcp 10.0.0.1"), "call billing", WithPublicMessage("Billing is unavailable"))
+
+	code, message := GRPCStatus(err, ExposePublic)
+	assertions.Equal(GRPCUnavailable, code)
+	assertions.Equal("Billing is unavailable", message)
+	_, message = GRPCStatus(err, ExposeInternal)
+	assertions.Equal(err.Error(), message)
+
+	public := NewPublicError(err, ExposePublic)
+	assertions.Equal(PublicError{Code: "Unavailable", Message: "Billing is unavailable", Type: "Unavailable"}, public)
+	internal := NewPublicError(err, ExposeInternal)
+	if assertions.NotNil(internal.Internal) {
+		assertions.Equal(Fingerprint(err), internal.Internal.Fingerprint)
+	}
+}
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	errs "github.com/pkg/errors"
+)
+
+// PanicBaggageKey is a baggage key of the recovered panic value
+const PanicBaggageKey = "panic"
+
+// panicMessage is a message of error that has been created from the recovered panic
+const panicMessage = "panic"
+
+// maxPanicDepth is a max count of frames of the panicking goroutine stack
+const maxPanicDepth = 64
+
+// Recover converts recovered panic into CustomError and stores it in err. It must be called with defer directly:
+//
+//	func Do() (err error) {
+//		defer errors.Recover(&err)
+//		//....
+//	}
+func Recover(err *error) {
+	if value := recover(); value != nil {
+		*err = newPanicErr(value)
+	}
+}
+
+// RecoverFunc converts recovered panic into CustomError and passes it into handler. It must be called with defer directly:
+//
+//	go func() {
+//		defer errors.RecoverFunc(func(err errors.CustomError) { log(err) })
+//		//....
+//	}()
+func RecoverFunc(handler func(err CustomError)) {
+	if value := recover(); value != nil {
+		handler(newPanicErr(value))
+	}
+}
+
+// Catch calls fn and returns its error, if fn panics then panic is converted into CustomError
+func Catch(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+// newPanicErr create custom error from the panic value with InternalError type and Panic severity.
+// Error values are wrapped, other values are formatted into message. It must be called from the deferred function
+func newPanicErr(value interface{}) CustomError {
+	baggage := ErrorBaggage{PanicBaggageKey: value}
+	stack := panicStack()
+	if err, ok := value.(error); ok {
+		level := DefaultLevel
+		if customErr, ok := err.(CustomError); ok {
+			level = customErr.GetLevel()
+		}
+		return newCustomErr(InternalError, baggage, level, Panic, &stackErr{err: errs.WithMessage(err, panicMessage), stack: stack})
+	}
+	return newCustomErr(InternalError, baggage, DefaultLevel, Panic,
+		&stackErr{err: fmt.Errorf("%s: %v", panicMessage, value), stack: stack})
+}
+
+// panicStack returns stack of the panicking goroutine. The first frame is the last runtime frame of panic
+// (e.g. runtime.gopanic) so the panicking function is the frame that is used as error path
+func panicStack() []uintptr {
+	pcs := make([]uintptr, maxPanicDepth)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+
+	start := -1
+	for k, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			start = k
+			break
+		}
+	}
+	if start < 0 {
+		// panic frame has not been found, skip panicStack, newPanicErr and Recover frames
+		if len(pcs) > 3 {
+			return pcs[2:]
+		}
+		return pcs
+	}
+	// skip runtime frames that have caused panic (e.g. runtime.sigpanic for nil pointer dereference)
+	for start+1 < len(pcs) {
+		fn := runtime.FuncForPC(pcs[start+1] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+		start++
+	}
+	return pcs[start:]
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	errs "errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var panicLine int
+
+func panicWith(value interface{}) {
+	_, _, panicLine, _ = runtime.Caller(0)
+	panic(value)
+}
+
+func recoverPanic(value interface{}) (err error) {
+	defer Recover(&err)
+	panicWith(value)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	assertions := assert.New(t)
+	_, fn, _, _ := runtime.Caller(0)
+
+	err := recoverPanic("something went wrong")
+	customErr, ok := err.(CustomError)
+	assertions.True(ok, "Check that panic is converted into CustomError")
+	assertions.Equal(InternalError, customErr.GetType())
+	assertions.Equal(Panic, customErr.GetSeverity())
+	assertions.Equal("something went wrong", customErr.GetBaggage()[PanicBaggageKey])
+	assertions.Equal("panic: something went wrong", customErr.Error())
+	assertions.Regexp(regexp.MustCompile(fmt.Sprintf("panicWith\\s+%s:%d$", regexp.QuoteMeta(fn), panicLine+1)), customErr.GetPath().String(),
+		"Check that path is a place of panic")
+
+	trace := customErr.GetTraceSlice()
+	assertions.Len(trace, 2)
+	assertions.Contains(trace[1], "recoverPanic", "Check that trace contains panicking goroutine stack")
+
+	assertions.NoError(Catch(func() error { return nil }))
+}
+
+func TestRecover_Error(t *testing.T) {
+	assertions := assert.New(t)
+
+	cause := errs.New("native error")
+	err := recoverPanic(cause)
+	assertions.True(errs.Is(err, cause), "Check that error panic value is wrapped")
+	assertions.Equal("panic: native error", err.Error())
+	assertions.Equal(cause, Cause(err.(CustomError)))
+
+	customCause := NotFound.New(DataLevel, nil, Warning, "not found")
+	err = Catch(func() error {
+		panic(customCause)
+	})
+	customErr := err.(CustomError)
+	assertions.Equal(InternalError, customErr.GetType())
+	assertions.Equal(Panic, customErr.GetSeverity())
+	assertions.Equal(DataLevel, customErr.GetLevel(), "Check that level of custom error is kept")
+	assertions.Equal(customCause, customErr.Unwrap())
+	assertions.True(customErr.IsMessageExistInStack("not found"))
+
+	returnedErr := errs.New("returned")
+	assertions.Equal(returnedErr, Catch(func() error { return returnedErr }))
+}
+
+func TestRecoverFunc(t *testing.T) {
+	assertions := assert.New(t)
+
+	var recovered CustomError
+	func() {
+		defer RecoverFunc(func(err CustomError) { recovered = err })
+		var m map[string]int
+		m["key"]++
+	}()
+	assertions.NotNil(recovered)
+	assertions.Contains(recovered.Error(), "assignment to entry in nil map")
+	assertions.Contains(recovered.GetPath().String(), "TestRecoverFunc", "Check that runtime frames are skipped")
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RemoteError is the original error of CustomError that has been received from another service
+type RemoteError struct {
+	// Service is a name of service where error happened
+	Service string
+	// Message is a message of the deepest error in the remote error stack
+	Message string
+}
+
+// Error implement error interface support
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+// IsRemote returns true if error has been received from another service
+func IsRemote(err error) bool {
+	var remoteErr *RemoteError
+	return errors.As(err, &remoteErr)
+}
+
+// RemoteService returns name of service where error happened if error has been received from another service
+func RemoteService(err error) (string, bool) {
+	var remoteErr *RemoteError
+	if errors.As(err, &remoteErr) {
+		return remoteErr.Service, true
+	}
+	return "", false
+}
+
+// NewRemote restores CustomError stack from the record that has been received from the service.
+// Layers keep error type, level, severity, message and baggage, unknown type, level and severity values
+// are replaced with default ones. The deepest error of the stack is RemoteError
+func NewRemote(service string, record ErrorRecord) CustomError {
+	stack := record.Stack
+	if len(stack) == 0 {
+		stack = []LayerRecord{{Message: record.Error}}
+	}
+
+	var result CustomError
+	for k := len(stack) - 1; k >= 0; k-- {
+		layer := stack[k]
+		var wrappedErr error
+		if result == nil {
+			message := layer.Message
+			if record.Cause != "" {
+				message = record.Cause
+			}
+			wrappedErr = &RemoteError{Service: service, Message: message}
+			if message != layer.Message {
+				wrappedErr = &transparent{err: fmt.Errorf("%s: %w", layer.Message, wrappedErr)}
+			}
+		} else {
+			wrappedErr = &transparent{err: fmt.Errorf("%s: %w", layer.Message, result)}
+		}
+		errType, _ := ParseErrorType(layer.Type)
+		level, _ := ParseErrorLevel(layer.Level)
+		severity, _ := ParseErrorSeverity(layer.Severity)
+		baggage := make(ErrorBaggage, len(layer.Baggage))
+		for key, value := range layer.Baggage {
+			baggage[key] = value
+		}
+		if result == nil && len(record.Violations) > 0 {
+			// violations are stored in the record once, so they are restored in the deepest layer
+			baggage[ViolationsBaggageKey] = record.Violations
+		}
+		// policies are not applied as attributes have been set up by the remote service
+		result = &customErr{
+			errType:       errType,
+			baggage:       baggage,
+			level:         level,
+			severity:      severity,
+			wrappedErr:    wrappedErr,
+			code:          ErrorCode(layer.Code),
+			publicMessage: ErrorMessage(layer.PublicMessage),
+			publicCode:    ErrorCode(layer.PublicCode),
+		}
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package errors
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RenderMode controls how errors are rendered by Render and ErrorRecord.Render
+type RenderMode int
+
+const (
+	// RenderFull renders errors as is
+	RenderFull RenderMode = iota
+	// RenderDeterministic renders errors the same way on every machine and after unrelated code changes,
+	// so rendered errors could be compared with golden files: module roots, GOPATH and GOROOT are trimmed
+	// from file paths, line numbers, addresses and fingerprints are replaced by placeholders
+	RenderDeterministic
+)
+
+// Placeholders of RenderDeterministic mode
+const (
+	LinePlaceholder        = "<line>"
+	AddressPlaceholder     = "<addr>"
+	FingerprintPlaceholder = "<fingerprint>"
+)
+
+var (
+	// sourcePathRegexp matches absolute paths of go files with line numbers, e.g. in error paths and panic traces
+	sourcePathRegexp = regexp.MustCompile(`(?:[A-Za-z]:)?[/\\][^\s:"'()]*\.go\b(?::\d+)?`)
+	addressRegexp    = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+	// moduleRoots caches module root of directories, empty string means that directory is not in a module
+	moduleRoots   = make(map[string]string)
+	moduleRootsMu sync.Mutex
+)
+
+// Render returns multi-line text representation of the error chain: message, layers from the top one
+// to the deepest one with codes, paths and sorted baggage, cause and violations
+//
+//	load user: no rows
+//	#0 NotFound DataLevel Warning: load user
+//	    at github.com/acme/users/repo.(*Repo).Load (repo/user.go:<line>)
+//	    baggage: userID=42
+//	cause: no rows
+func Render(err error, mode RenderMode) string {
+	if err == nil {
+		return ""
+	}
+	customErr, ok := FindInStack(err, func(CustomError) bool { return true })
+	if !ok {
+		return normalize(err.Error(), mode) + "\n"
+	}
+	return NewErrorRecord(customErr).Render(mode)
+}
+
+// Render returns multi-line text representation of the record, see errors.Render
+func (r ErrorRecord) Render(mode RenderMode) string {
+	r = r.Normalize(mode)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, r.Error)
+	for k, layer := range r.Stack {
+		fmt.Fprintf(&b, "#%d %s %s %s: %s\n", k, layer.Type, layer.Level, layer.Severity, layer.Message)
+		if layer.Code != "" {
+			fmt.Fprintf(&b, "    code: %s\n", layer.Code)
+		}
+		if layer.PublicMessage != "" || layer.PublicCode != "" {
+			fmt.Fprintf(&b, "    public: %s (%s)\n", layer.PublicMessage, layer.PublicCode)
+		}
+		if layer.Path != "" {
+			parts := strings.SplitN(layer.Path, "\n\t", 2)
+			function, file := parts[0], ""
+			if len(parts) == 2 {
+				file = parts[1]
+			}
+			fmt.Fprintf(&b, "    at %s (%s)\n", function, file)
+		}
+		if len(layer.Baggage) > 0 {
+			fmt.Fprintf(&b, "    baggage: %s\n", renderBaggage(layer.Baggage))
+		}
+	}
+	if r.Cause != "" {
+		fmt.Fprintf(&b, "cause: %s\n", r.Cause)
+	}
+	for _, v := range r.Violations {
+		fmt.Fprintf(&b, "violation: %s (%s): %s\n", v.Field, v.Rule, v.Message)
+	}
+	return b.String()
+}
+
+// Normalize returns copy of the record for the render mode, the record is returned as is for RenderFull mode
+func (r ErrorRecord) Normalize(mode RenderMode) ErrorRecord {
+	if mode != RenderDeterministic {
+		return r
+	}
+	if r.Fingerprint != "" {
+		r.Fingerprint = FingerprintPlaceholder
+	}
+	r.Error = normalize(r.Error, mode)
+	r.Cause = normalize(r.Cause, mode)
+	stack := make([]LayerRecord, len(r.Stack))
+	for k, layer := range r.Stack {
+		layer.Message = normalize(layer.Message, mode)
+		layer.Path = normalize(layer.Path, mode)
+		stack[k] = layer
+	}
+	r.Stack = stack
+	return r
+}
+
+// normalize replaces source paths and addresses in text for RenderDeterministic mode
+func normalize(text string, mode RenderMode) string {
+	if mode != RenderDeterministic || text == "" {
+		return text
+	}
+	text = sourcePathRegexp.ReplaceAllStringFunc(text, func(path string) string {
+		file, line := path, ""
+		if k := strings.LastIndex(path, ".go:"); k >= 0 {
+			file, line = path[:k+3], ":"+LinePlaceholder
+		}
+		return trimSourceRoot(file) + line
+	})
+	return addressRegexp.ReplaceAllString(text, AddressPlaceholder)
+}
+
+// trimSourceRoot returns path of go file relative to the module root, GOPATH or GOROOT.
+// Files from the module cache keep module path and version, e.g. github.com/pkg/errors@v0.9.1/errors.go
+func trimSourceRoot(file string) string {
+	file = filepath.Clean(file)
+	prefixes := []string{filepath.Join(build.Default.GOROOT, "src")}
+	for _, gopath := range filepath.SplitList(build.Default.GOPATH) {
+		prefixes = append(prefixes, filepath.Join(gopath, "pkg", "mod"), filepath.Join(gopath, "src"))
+	}
+	for _, prefix := range prefixes {
+		if rel, err := filepath.Rel(prefix, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	if root := moduleRoot(filepath.Dir(file)); root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// moduleRoot returns the nearest directory with go.mod file
+func moduleRoot(dir string) string {
+	moduleRootsMu.Lock()
+	defer moduleRootsMu.Unlock()
+
+	var visited []string
+	root := ""
+	for {
+		if cached, ok := moduleRoots[dir]; ok {
+			root = cached
+			break
+		}
+		visited = append(visited, dir)
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			root = dir
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	for _, v := range visited {
+		moduleRoots[v] = root
+	}
+	return root
+}
+
+// renderBaggage returns baggage as key=value pairs sorted by keys
+func renderBaggage(baggage ErrorBaggage) string {
+	keys := make([]string, 0, len(baggage))
+	for key := range baggage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, baggage[key]))
+	}
+	return strings.Join(pairs, " ")
+}
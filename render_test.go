@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	assertions := assert.New(t)
+
+	base := NotFound.NewBase("no rows").SetBaggage(ErrorBaggage{"table": "users", "id": 7})
+	err := WrapWith(base, "load user", WithLevel(DataLevel), WithSeverity(Warning), WithPublicCode("user.not_found"))
+
+	expected := strings.Join([]string{
+		"load user: no rows",
+		"#0 NotFound DataLevel Warning: load user",
+		"    public:  (user.not_found)",
+		"    at github.com/Darevski/go-custom-errors.TestRender (render_test.go:<line>)",
+		"#1 NotFound DefaultLevel DefaultSeverity: no rows",
+		"    at github.com/Darevski/go-custom-errors.TestRender (render_test.go:<line>)",
+		"    baggage: id=7 table=users",
+		"cause: no rows",
+		"",
+	}, "\n")
+	assertions.Equal(expected, Render(err, RenderDeterministic))
+	assertions.Equal(expected, Render(fmt.Errorf("handler: %w", err), RenderDeterministic), "Check the top custom error is rendered")
+
+	full := Render(err, RenderFull)
+	assertions.Regexp(`render_test\.go:\d+\)`, full)
+	assertions.Contains(full, filepath.ToSlash(moduleRoot(".")))
+
+	record := NewErrorRecord(err).Normalize(RenderDeterministic)
+	assertions.Equal(FingerprintPlaceholder, record.Fingerprint)
+	assertions.Equal("github.com/Darevski/go-custom-errors.TestRender\n\trender_test.go:<line>", record.Stack[0].Path)
+	assertions.NotEqual(FingerprintPlaceholder, NewErrorRecord(err).Fingerprint, "Check that record is copied")
+
+	assertions.Equal("", Render(nil, RenderDeterministic))
+	assertions.Equal("panic at <addr>\n", Render(fmt.Errorf("panic at 0xc000012345"), RenderDeterministic))
+}
+
+func TestNormalize(t *testing.T) {
+	assertions := assert.New(t)
+	root := moduleRoot(".")
+
+	for _, testCase := range []struct {
+		text     string
+		expected string
+	}{
+		{text: filepath.Join(root, "errstore", "store.go") + ":123", expected: "errstore/store.go:<line>"},
+		{text: "main.main()\n\t" + filepath.Join(root, "cmd", "cerr", "main.go") + ":12 +0x1d", expected: "main.main()\n\tcmd/cerr/main.go:<line> +<addr>"},
+		{text: "/nowhere/file.go", expected: "/nowhere/file.go"},
+		{text: "see golang.org and github.com/acme/cargo.gopher", expected: "see golang.org and github.com/acme/cargo.gopher"},
+	} {
+		assertions.Equal(testCase.expected, normalize(testCase.text, RenderDeterministic), testCase.text)
+		assertions.Equal(testCase.text, normalize(testCase.text, RenderFull))
+	}
+}
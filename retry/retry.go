@@ -0,0 +1,161 @@
+// Package retry retries operations depending on classification of returned custom errors.
+//
+// Error is retried if it is retryable according to errors.IsRetryable: the explicit retryable flag of error
+// or the retryable flag of its type (e.g. Unavailable and Timeout are retried, InvalidArguments is not).
+// The delay between attempts grows exponentially with jitter, the retry-after hint of error is used instead
+// of the computed delay if it is set. Both delays are limited by MaxDelay
+//
+//	err := retry.Do(ctx, retry.Policy{MaxAttempts: 5}, func(ctx context.Context) error {
+//		return client.Call(ctx)
+//	})
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// Default policy values
+const (
+	DefaultMaxAttempts  = 3
+	DefaultInitialDelay = 100 * time.Millisecond
+	DefaultMaxDelay     = 10 * time.Second
+	DefaultMultiplier   = 2
+	DefaultJitter       = 0.2
+)
+
+// NoJitter disables jitter of policy as zero Jitter is replaced with DefaultJitter
+const NoJitter = -1
+
+// Clock is a source of timers, it could be replaced in tests
+type Clock interface {
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is Clock based on time package
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Policy describes how operation is retried, zero values are replaced with default ones
+type Policy struct {
+	// MaxAttempts is a max count of operation calls including the first one
+	MaxAttempts int
+	// InitialDelay is a delay before the second attempt
+	InitialDelay time.Duration
+	// MaxDelay limits delay between attempts including jitter and retry-after hints of errors
+	MaxDelay time.Duration
+	// Multiplier increases delay after every attempt
+	Multiplier float64
+	// Jitter is a fraction of delay that is randomly added or subtracted, e.g. 0.2 means ±20%.
+	// Negative value (e.g. NoJitter) disables jitter
+	Jitter float64
+	// Retryable decides if error should be retried, errors.IsRetryable is used by default
+	Retryable func(err error) bool
+	// Clock is used to wait between attempts
+	Clock Clock
+	// Rand returns random numbers in [0, 1) for jitter
+	Rand func() float64
+}
+
+// withDefaults returns policy where zero values are replaced with default ones
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = DefaultInitialDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultMaxDelay
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultMultiplier
+	}
+	if p.Jitter == 0 || p.Jitter > 1 {
+		p.Jitter = DefaultJitter
+	}
+	if p.Retryable == nil {
+		p.Retryable = cErrors.IsRetryable
+	}
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+	if p.Rand == nil {
+		p.Rand = rand.Float64
+	}
+	return p
+}
+
+// Delay returns delay before the next attempt after attempt with specified number (starting from 1)
+func (p Policy) Delay(attempt int) time.Duration {
+	p = p.withDefaults()
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		delay *= 1 - p.Jitter + 2*p.Jitter*p.Rand()
+	}
+	if delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// Do calls fn until it succeeds, returns not retryable error, attempts are exhausted or context is done.
+// If operation has not succeeded then MultipleCustomErrs with errors of every attempt is returned,
+// errors that are not CustomError are wrapped. Context error is added as Canceled or Timeout error
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	policy = policy.withDefaults()
+	errs := cErrors.NewMultiply()
+
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			errs.AddErr(contextErr(ctx.Err()))
+			return errs
+		}
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		errs.AddErr(attemptErr(err, attempt))
+		if attempt >= policy.MaxAttempts || !policy.Retryable(err) {
+			return errs
+		}
+
+		delay, ok := cErrors.RetryAfter(err)
+		if !ok {
+			delay = policy.Delay(attempt)
+		}
+		if delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+		select {
+		case <-ctx.Done():
+			errs.AddErr(contextErr(ctx.Err()))
+			return errs
+		case <-policy.Clock.After(delay):
+		}
+	}
+}
+
+// attemptErr returns error of attempt as CustomError
+func attemptErr(err error, attempt int) cErrors.CustomError {
+	if customErr, ok := err.(cErrors.CustomError); ok {
+		return customErr
+	}
+	return cErrors.WrapF(err, "attempt %d", attempt)
+}
+
+// contextErr converts context error into CustomError
+func contextErr(err error) cErrors.CustomError {
+	if err == context.DeadlineExceeded {
+		return cErrors.Timeout.Wrap(err, "retry deadline exceeded")
+	}
+	return cErrors.Canceled.Wrap(err, "retry canceled")
+}
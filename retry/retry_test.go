@@ -0,0 +1,152 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock does not wait and records requested delays
+type fakeClock struct {
+	delays []time.Duration
+	// onAfter is called on every waiting
+	onAfter func()
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	if c.onAfter != nil {
+		c.onAfter()
+	}
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func testPolicy(clock *fakeClock) Policy {
+	return Policy{
+		MaxAttempts:  4,
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Second,
+		Clock:        clock,
+		Rand:         func() float64 { return 0.5 },
+	}
+}
+
+func TestDo(t *testing.T) {
+	assertions := assert.New(t)
+	clock := &fakeClock{}
+
+	calls := 0
+	err := Do(context.Background(), testPolicy(clock), func(ctx context.Context) error {
+		calls++
+		return cErrors.Unavailable.NewBase("unavailable")
+	})
+	assertions.Equal(4, calls, "Check that retryable errors are retried until attempts are exhausted")
+	assertions.Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, clock.delays)
+	multiple, ok := err.(cErrors.MultipleCustomErrs)
+	assertions.True(ok)
+	assertions.Len(multiple.GetErrs(), 4, "Check that errors of every attempt are returned")
+	assertions.True(errors.Is(err, cErrors.Unavailable.NewBase("")))
+
+	calls = 0
+	err = Do(context.Background(), testPolicy(&fakeClock{}), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return cErrors.Timeout.NewBase("timeout")
+		}
+		return nil
+	})
+	assertions.NoError(err)
+	assertions.Equal(3, calls)
+}
+
+func TestDo_Classification(t *testing.T) {
+	assertions := assert.New(t)
+
+	cases := []struct {
+		name  string
+		err   error
+		calls int
+	}{
+		{name: "not retryable type", err: cErrors.InvalidArguments.NewBase("invalid"), calls: 1},
+		{name: "explicit flag", err: cErrors.SetRetryable(cErrors.InternalError.NewBase("internal"), true), calls: 4},
+		{name: "explicit flag overrides type", err: cErrors.Make("unavailable", cErrors.WithType(cErrors.Unavailable), cErrors.WithRetryable(false)), calls: 1},
+		{name: "wrapped flag", err: cErrors.Wrap(cErrors.SetRetryable(cErrors.NewBase("base"), true), "wrapped"), calls: 4},
+		{name: "native error", err: errors.New("native"), calls: 1},
+	}
+	for _, v := range cases {
+		calls := 0
+		err := Do(context.Background(), testPolicy(&fakeClock{}), func(ctx context.Context) error {
+			calls++
+			return v.err
+		})
+		assertions.Equal(v.calls, calls, "Check %s", v.name)
+		assertions.Len(err.(cErrors.MultipleCustomErrs).GetErrs(), v.calls, "Check %s", v.name)
+	}
+}
+
+func TestDo_RetryAfter(t *testing.T) {
+	assertions := assert.New(t)
+	clock := &fakeClock{}
+
+	_ = Do(context.Background(), testPolicy(clock), func(ctx context.Context) error {
+		return cErrors.Make("rate limited", cErrors.WithType(cErrors.RateLimited), cErrors.WithRetryAfter(2*time.Second))
+	})
+	assertions.Equal([]time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second}, clock.delays)
+
+	clock = &fakeClock{}
+	_ = Do(context.Background(), testPolicy(clock), func(ctx context.Context) error {
+		return cErrors.Make("rate limited", cErrors.WithType(cErrors.RateLimited), cErrors.WithRetryAfter(30*time.Second))
+	})
+	assertions.Equal([]time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}, clock.delays, "Check that hint is limited by max delay")
+}
+
+func TestDo_Context(t *testing.T) {
+	assertions := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := &fakeClock{onAfter: cancel}
+
+	calls := 0
+	err := Do(ctx, testPolicy(clock), func(ctx context.Context) error {
+		calls++
+		return cErrors.Unavailable.NewBase("unavailable")
+	})
+	assertions.Equal(1, calls)
+	errs := err.(cErrors.MultipleCustomErrs).GetErrs()
+	assertions.Len(errs, 2)
+	assertions.True(errors.Is(err, context.Canceled))
+	assertions.Equal(cErrors.Canceled, errs[1].GetType())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err = Do(ctx, testPolicy(&fakeClock{}), func(ctx context.Context) error {
+		return nil
+	})
+	assertions.True(errors.Is(err, context.DeadlineExceeded), "Check that done context is checked before the first attempt")
+	assertions.True(errors.Is(err, cErrors.Timeout.NewBase("")))
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	assertions := assert.New(t)
+	policy := Policy{InitialDelay: time.Second, MaxDelay: 3 * time.Second, Jitter: 0.5, Rand: func() float64 { return 0 }}
+
+	assertions.Equal(500*time.Millisecond, policy.Delay(1))
+	assertions.Equal(time.Second, policy.Delay(2))
+	assertions.Equal(2*time.Second, policy.Delay(3))
+	assertions.Equal(3*time.Second, policy.Delay(5), "Check max delay")
+
+	policy.Rand = func() float64 { return 0.999 }
+	assertions.InDelta(float64(3*time.Second), float64(policy.Delay(2)), float64(5*time.Millisecond))
+	assertions.Equal(3*time.Second, policy.Delay(3), "Check that max delay is applied after jitter")
+
+	policy = Policy{InitialDelay: time.Second, Rand: func() float64 { return 0 }}
+	assertions.Equal(800*time.Millisecond, policy.Delay(1), "Check that zero jitter is replaced with default one")
+	policy.Jitter = NoJitter
+	assertions.Equal(time.Second, policy.Delay(1), "Check that jitter could be disabled")
+	assertions.Equal(time.Second, policy.withDefaults().Delay(1), "Check that disabled jitter is kept by defaults")
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Baggage keys of retry hints
+const (
+	// RetryableBaggageKey is a baggage key of explicit retryable flag
+	RetryableBaggageKey = "retryable"
+	// RetryAfterBaggageKey is a baggage key of the delay hint before the next retry
+	RetryAfterBaggageKey = "retryAfter"
+)
+
+// WithRetryable sets explicit retryable flag of error, it overrides retryable flag of error type
+func WithRetryable(retryable bool) Option {
+	return WithBaggage(ErrorBaggage{RetryableBaggageKey: retryable})
+}
+
+// WithRetryAfter sets delay hint before the next retry
+func WithRetryAfter(delay time.Duration) Option {
+	return WithBaggage(ErrorBaggage{RetryAfterBaggageKey: delay})
+}
+
+// SetRetryable sets explicit retryable flag of error, it overrides retryable flag of error type
+func SetRetryable(err CustomError, retryable bool) CustomError {
+	return err.AddBaggage(ErrorBaggage{RetryableBaggageKey: retryable})
+}
+
+// SetRetryAfter sets delay hint before the next retry
+func SetRetryAfter(err CustomError, delay time.Duration) CustomError {
+	return err.AddBaggage(ErrorBaggage{RetryAfterBaggageKey: delay})
+}
+
+// IsRetryable returns true if operation that returned error could be retried.
+// The explicit flag of the first layer in the error chain that has it is used,
+// otherwise the error type of the top custom error is used, see ErrorType.Retryable.
+// Flags of remote and decoded errors could be strings ("true", "1") or numbers. Errors without custom layers are not retryable
+func IsRetryable(err error) bool {
+	if layer, ok := FindInStack(err, HasBaggageKey(RetryableBaggageKey)); ok {
+		if retryable, ok := parseRetryable(layer.GetBaggage()[RetryableBaggageKey]); ok {
+			return retryable
+		}
+	}
+	if layer, ok := FindInStack(err, func(CustomError) bool { return true }); ok {
+		return layer.GetType().Retryable()
+	}
+	return false
+}
+
+// RetryAfter returns delay hint of the first layer in the error chain that has it.
+// Hints of remote and decoded errors could be duration strings ("1.5s") or numbers of nanoseconds,
+// as time.Duration is encoded into JSON
+func RetryAfter(err error) (time.Duration, bool) {
+	layer, ok := FindInStack(err, HasBaggageKey(RetryAfterBaggageKey))
+	if !ok {
+		return 0, false
+	}
+	return parseRetryAfter(layer.GetBaggage()[RetryAfterBaggageKey])
+}
+
+// parseRetryable converts retryable flag of baggage into bool, numbers are true if they are not 0
+func parseRetryable(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		retryable, err := strconv.ParseBool(v)
+		return retryable, err == nil
+	}
+	number, ok := toInt(value)
+	return number != 0, ok
+}
+
+// parseRetryAfter converts delay hint of baggage into duration, numbers are nanoseconds
+func parseRetryAfter(value interface{}) (time.Duration, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, true
+	case string:
+		if delay, err := time.ParseDuration(v); err == nil {
+			return delay, true
+		}
+	}
+	nanoseconds, ok := toInt(value)
+	return time.Duration(nanoseconds), ok
+}
+
+// toInt converts integer, float and string values to int64
+func toInt(value interface{}) (int64, bool) {
+	if s, ok := value.(string); ok {
+		n, err := strconv.ParseInt(s, 10, 64)
+		return n, err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int64(v.Float()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	errs "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.True(IsRetryable(Unavailable.NewBase("unavailable")))
+	assertions.True(IsRetryable(Wrap(Timeout.NewBase("timeout"), "wrapped")))
+	assertions.False(IsRetryable(InvalidArguments.NewBase("invalid")))
+	assertions.False(IsRetryable(errs.New("native")))
+	assertions.True(IsRetryable(SetRetryable(InternalError.NewBase("internal"), true)), "Check explicit flag")
+	assertions.False(IsRetryable(Wrap(SetRetryable(Unavailable.NewBase("unavailable"), false), "wrapped")), "Check explicit flag in chain")
+
+	for _, v := range []interface{}{"true", "1", float64(1), 1} {
+		err := Make("remote", WithType(InternalError), WithBaggage(ErrorBaggage{RetryableBaggageKey: v}))
+		assertions.True(IsRetryable(err), "Check remote flag %#v", v)
+	}
+	for _, v := range []interface{}{"false", "0", float64(0)} {
+		err := Make("remote", WithType(Unavailable), WithBaggage(ErrorBaggage{RetryableBaggageKey: v}))
+		assertions.False(IsRetryable(err), "Check remote flag %#v", v)
+	}
+	err := Make("remote", WithType(Unavailable), WithBaggage(ErrorBaggage{RetryableBaggageKey: "maybe"}))
+	assertions.True(IsRetryable(err), "Check that invalid flag is ignored")
+}
+
+func TestRetryAfter(t *testing.T) {
+	assertions := assert.New(t)
+
+	_, ok := RetryAfter(RateLimited.NewBase("rate limited"))
+	assertions.False(ok)
+
+	delay, ok := RetryAfter(Wrap(SetRetryAfter(RateLimited.NewBase("rate limited"), time.Minute), "wrapped"))
+	assertions.True(ok)
+	assertions.Equal(time.Minute, delay)
+
+	delay, ok = RetryAfter(Make("remote", WithBaggage(ErrorBaggage{RetryAfterBaggageKey: "1.5s"})))
+	assertions.True(ok, "Check string hint")
+	assertions.Equal(1500*time.Millisecond, delay)
+
+	for _, v := range []interface{}{float64(1500000000), "1500000000", int64(1500000000)} {
+		delay, ok = RetryAfter(Make("remote", WithBaggage(ErrorBaggage{RetryAfterBaggageKey: v})))
+		assertions.True(ok, "Check remote hint %#v", v)
+		assertions.Equal(1500*time.Millisecond, delay, "Check remote hint %#v", v)
+	}
+	_, ok = RetryAfter(Make("remote", WithBaggage(ErrorBaggage{RetryAfterBaggageKey: "soon"})))
+	assertions.False(ok)
+}
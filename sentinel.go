@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+
+	errs "github.com/pkg/errors"
+)
+
+// sentinels contain all registered sentinel errors by their codes
+var sentinels = struct {
+	sync.RWMutex
+	byCode map[ErrorCode]CustomError
+}{byCode: make(map[ErrorCode]CustomError)}
+
+// NewSentinel create named sentinel error with stable code, e.g. "user.not_found".
+// Unlike other errors sentinels are compared by errors.Is with their identity or code instead of ErrorType,
+// so errors.Is(err, ErrUserNotFound) is false for other NotFound errors:
+//
+//	var ErrUserNotFound = errors.NewSentinel("user.not_found", errors.NotFound, "user not found")
+//
+//	err := errors.Wrap(ErrUserNotFound, "load user") // err.GetCode() == "user.not_found"
+//	errors.Is(err, ErrUserNotFound)                  // true
+//
+// Errors that wrap sentinel keep its code. Options could be used to set up level, severity and baggage.
+// Public message and code could be set with options too. Code must be unique, NewSentinel panics if the code is already registered.
+// Type based comparison is available with IsType function
+func NewSentinel(code ErrorCode, errType ErrorType, message ErrorMessage, opts ...Option) CustomError {
+	if code == "" {
+		panic("errors: sentinel code must not be empty")
+	}
+	s := newSettings(opts)
+	err := newCustomErr(errType, s.baggage, s.level, s.severity, errs.New(message.String()))
+	s.setCode(err)
+	err.code = code
+
+	sentinels.Lock()
+	defer sentinels.Unlock()
+	if _, ok := sentinels.byCode[code]; ok {
+		panic(fmt.Sprintf("errors: sentinel with code %q is already registered", code))
+	}
+	sentinels.byCode[code] = err
+	return err
+}
+
+// LookupSentinel returns registered sentinel error by code
+func LookupSentinel(code ErrorCode) (CustomError, bool) {
+	sentinels.RLock()
+	defer sentinels.RUnlock()
+	err, ok := sentinels.byCode[code]
+	return err, ok
+}
+
+// IsType returns true if any CustomError in error chain has specified type.
+// It is an explicit type based comparison that works regardless of sentinel codes
+func IsType(err error, errType ErrorType) bool {
+	return MatchInStack(err, HasType(errType))
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	errs "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errUserNotFound  = NewSentinel("test.user.not_found", NotFound, "user not found", WithLevel(DataLevel))
+	errOrderNotFound = NewSentinel("test.order.not_found", NotFound, "order not found")
+)
+
+func TestNewSentinel(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.Equal(ErrorCode("test.user.not_found"), errUserNotFound.GetCode())
+	assertions.Equal(NotFound, errUserNotFound.GetType())
+	assertions.Equal(DataLevel, errUserNotFound.GetLevel())
+	assertions.Equal("user not found", errUserNotFound.Error())
+
+	found, ok := LookupSentinel("test.user.not_found")
+	assertions.True(ok)
+	assertions.Equal(errUserNotFound, found)
+	_, ok = LookupSentinel("test.unknown")
+	assertions.False(ok)
+
+	assertions.Panics(func() { NewSentinel("test.user.not_found", NotFound, "duplicate") }, "Check code uniqueness")
+	assertions.Panics(func() { NewSentinel("", NotFound, "empty") }, "Check empty code")
+}
+
+func TestSentinel_Is(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := Wrap(errUserNotFound, "load user")
+	assertions.Equal(errUserNotFound.GetCode(), err.GetCode(), "Check that wrapping keeps code")
+	assertions.Equal(NotFound, err.GetType())
+	assertions.True(errs.Is(err, errUserNotFound))
+	assertions.False(errs.Is(err, errOrderNotFound), "Check that sentinels of the same type are different")
+	assertions.False(errs.Is(NotFound.NewBase("not found"), errUserNotFound), "Check that not sentinel errors do not match sentinel")
+
+	err = InternalError.WrapF(fmt.Errorf("native: %w", err), "get user %d", 1)
+	assertions.Equal(ErrorCode(""), err.GetCode(), "Check that code is not inherited through native errors")
+	assertions.True(errs.Is(err, errUserNotFound), "Check that sentinel is found deeper in chain")
+
+	assertions.True(errs.Is(errOrderNotFound, NotFound.NewBase("")), "Check that type comparison of plain targets is kept")
+	assertions.True(IsType(err, NotFound), "Check explicit type comparison")
+	assertions.False(IsType(err, BadRequest))
+	assertions.True(MatchInStack(err, HasCode("test.user.not_found")))
+
+	record := NewErrorRecord(Wrap(errUserNotFound, "load user"))
+	remote := NewRemote("users", record)
+	assertions.True(errs.Is(remote, errUserNotFound), "Check that remote errors keep code")
+	assertions.False(errs.Is(remote, errOrderNotFound))
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+
+	errs "github.com/pkg/errors"
+)
+
+// maxStackDepth is a max count of frames in captured stack
+const maxStackDepth = 32
+
+// callers returns stack of the current goroutine, skip is a count of frames to skip
+// where 0 is the frame of the callers caller
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(skip+2, pcs)]
+}
+
+// transparent wraps error without changing its message, so CustomError.Unwrap returns the wrapped error itself
+type transparent struct {
+	err error
+}
+
+func (t *transparent) Error() string {
+	return t.err.Error()
+}
+
+func (t *transparent) Unwrap() error {
+	return t.err
+}
+
+// stackErr is a wrapped error with the stack trace, it is used when stack could not be captured by pkg/errors
+// (e.g. stack of translated error, error created on behalf of the caller or stack of panicking goroutine)
+type stackErr struct {
+	err   error
+	stack []uintptr
+}
+
+func (p *stackErr) Error() string {
+	return p.err.Error()
+}
+
+func (p *stackErr) Unwrap() error {
+	return p.err
+}
+
+// StackTrace implements stackTracer interface
+func (p *stackErr) StackTrace() errs.StackTrace {
+	frames := make([]errs.Frame, len(p.stack))
+	for k, pc := range p.stack {
+		frames[k] = errs.Frame(pc)
+	}
+	return frames
+}
+
+// Format prints error message with stack trace for %+v verb, analogous to pkg/errors errors
+func (p *stackErr) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, p.Error())
+			p.StackTrace().Format(s, verb)
+			return
+		}
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, p.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", p.Error())
+	}
+}
@@ -0,0 +1,136 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+)
+
+// Translator classifies error that is not CustomError, it returns error type and baggage of error
+// or false if error is not supported by translator
+type Translator func(err error) (errType ErrorType, baggage ErrorBaggage, ok bool)
+
+// translators contain registered translators and the flag of translation while wrapping
+var translators = struct {
+	sync.RWMutex
+	own    []Translator
+	onWrap bool
+}{}
+
+// builtinTranslators are used after own translators
+var builtinTranslators = []Translator{
+	translateSentinel(sql.ErrNoRows, NotFound),
+	translateSentinel(os.ErrNotExist, NotFound),
+	translateSentinel(os.ErrPermission, AccessDenied),
+	translateSentinel(context.DeadlineExceeded, Timeout),
+	translateSentinel(context.Canceled, Canceled),
+	translateJSON,
+	translateNet,
+}
+
+// RegisterTranslator adds own translator, own translators are used in order of registration before built-in ones
+func RegisterTranslator(translator Translator) {
+	translators.Lock()
+	defer translators.Unlock()
+	translators.own = append(translators.own, translator)
+}
+
+// ResetTranslators removes own translators and disables translation while wrapping
+func ResetTranslators() {
+	translators.Lock()
+	defer translators.Unlock()
+	translators.own = nil
+	translators.onWrap = false
+}
+
+// SetTranslateOnWrap enables or disables translation in Wrap, WrapF and WrapWith functions. If it is enabled then
+// wrapped errors that are not CustomError get type and baggage from translators (e.g. wrapped sql.ErrNoRows is NotFound)
+func SetTranslateOnWrap(enabled bool) {
+	translators.Lock()
+	defer translators.Unlock()
+	translators.onWrap = enabled
+}
+
+// Translate converts error into CustomError that wraps original error, type and baggage are set up by translators:
+//
+//	sql.ErrNoRows                              NotFound
+//	os.ErrNotExist (fs.ErrNotExist)            NotFound
+//	os.ErrPermission (fs.ErrPermission)        AccessDenied
+//	context.DeadlineExceeded                   Timeout
+//	context.Canceled                           Canceled
+//	*json.SyntaxError, *json.UnmarshalTypeError BadRequest with offset (and field) in baggage
+//	net.Error with Timeout() == true           Timeout
+//
+// Errors in the chain of wrapped error are also translated (errors.Is and errors.As are used).
+// CustomError is returned as is, error without translation has DefaultType, nil is returned for nil error
+func Translate(err error) CustomError {
+	if err == nil {
+		return nil
+	}
+	if customErr, ok := err.(CustomError); ok {
+		return customErr
+	}
+	errType, baggage, _ := translate(err)
+	return newCustomErr(errType, baggage, DefaultLevel, DefaultSeverity, &stackErr{err: &transparent{err: err}, stack: callers(0)})
+}
+
+// translate applies translators to error, empty baggage and DefaultType are returned if there is no translation
+func translate(err error) (ErrorType, ErrorBaggage, bool) {
+	translators.RLock()
+	own := translators.own
+	translators.RUnlock()
+
+	for _, list := range [][]Translator{own, builtinTranslators} {
+		for _, translator := range list {
+			if errType, baggage, ok := translator(err); ok {
+				if baggage == nil {
+					baggage = make(ErrorBaggage)
+				}
+				return errType, baggage, true
+			}
+		}
+	}
+	return DefaultType, make(ErrorBaggage), false
+}
+
+// translateOnWrap returns translation of wrapped error if translation while wrapping is enabled
+func translateOnWrap(err error) (ErrorType, ErrorBaggage, bool) {
+	translators.RLock()
+	enabled := translators.onWrap
+	translators.RUnlock()
+	if !enabled || err == nil {
+		return DefaultType, nil, false
+	}
+	return translate(err)
+}
+
+// translateSentinel create translator of sentinel error
+func translateSentinel(target error, errType ErrorType) Translator {
+	return func(err error) (ErrorType, ErrorBaggage, bool) {
+		return errType, nil, errors.Is(err, target)
+	}
+}
+
+func translateJSON(err error) (ErrorType, ErrorBaggage, bool) {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return BadRequest, ErrorBaggage{"offset": syntaxErr.Offset}, true
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return BadRequest, ErrorBaggage{"offset": typeErr.Offset, "field": typeErr.Field}, true
+	}
+	return DefaultType, nil, false
+}
+
+func translateNet(err error) (ErrorType, ErrorBaggage, bool) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return Timeout, nil, true
+	}
+	return DefaultType, nil, false
+}
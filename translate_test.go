@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timeoutErr is net.Error with timeout
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestTranslate(t *testing.T) {
+	assertions := assert.New(t)
+
+	_, openErr := os.Open("/not/existing/file")
+	var payload struct{ ID int }
+	syntaxErr := json.Unmarshal([]byte(`{"ID": }`), &payload)
+	typeErr := json.Unmarshal([]byte(`{"ID": "1"}`), &payload)
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: timeoutErr{}}
+
+	cases := []struct {
+		err     error
+		errType ErrorType
+	}{
+		{err: sql.ErrNoRows, errType: NotFound},
+		{err: fmt.Errorf("query: %w", sql.ErrNoRows), errType: NotFound},
+		{err: openErr, errType: NotFound},
+		{err: os.ErrPermission, errType: AccessDenied},
+		{err: context.DeadlineExceeded, errType: Timeout},
+		{err: context.Canceled, errType: Canceled},
+		{err: syntaxErr, errType: BadRequest},
+		{err: typeErr, errType: BadRequest},
+		{err: dialErr, errType: Timeout},
+		{err: errNativeReference, errType: DefaultType},
+	}
+	for k, v := range cases {
+		err := Translate(v.err)
+		assertions.Equal(v.errType, err.GetType(), "Check type of %d error: %v", k, v.err)
+		assertions.Equal(v.err, err.Unwrap(), "Check that %d error is wrapped", k)
+		assertions.Equal(v.err.Error(), err.Error(), "Check that %d error message is kept", k)
+		assertions.True(errs.Is(err, v.err))
+	}
+
+	var pathErr *os.PathError
+	assertions.True(errs.As(Translate(openErr), &pathErr), "Check that wrapped error type is kept")
+	assertions.Greater(Translate(syntaxErr).GetBaggage()["offset"], int64(0))
+	assertions.Equal("ID", Translate(typeErr).GetBaggage()["field"])
+
+	err := Translate(sql.ErrNoRows)
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check err path")
+
+	customErr := NotFound.NewBase("not found")
+	assertions.Equal(customErr, Translate(customErr))
+	assertions.Nil(Translate(nil))
+}
+
+func TestRegisterTranslator(t *testing.T) {
+	assertions := assert.New(t)
+	t.Cleanup(ResetTranslators)
+
+	RegisterTranslator(func(err error) (ErrorType, ErrorBaggage, bool) {
+		if errs.Is(err, sql.ErrNoRows) {
+			return InternalError, ErrorBaggage{"own": true}, true
+		}
+		return DefaultType, nil, false
+	})
+	err := Translate(sql.ErrNoRows)
+	assertions.Equal(InternalError, err.GetType(), "Check that own translators are used before built-in ones")
+	assertions.Equal(ErrorBaggage{"own": true}, err.GetBaggage())
+	assertions.Equal(NotFound, Translate(os.ErrNotExist).GetType())
+}
+
+func TestSetTranslateOnWrap(t *testing.T) {
+	assertions := assert.New(t)
+	t.Cleanup(ResetTranslators)
+
+	assertions.Equal(DefaultType, Wrap(sql.ErrNoRows, "load user").GetType(), "Check that translation is disabled by default")
+
+	SetTranslateOnWrap(true)
+	assertions.Equal(NotFound, Wrap(sql.ErrNoRows, "load user").GetType())
+	assertions.Equal(Timeout, WrapF(context.DeadlineExceeded, "load user %d", 1).GetType())
+	assertions.Equal(NotFound, WrapWith(sql.ErrNoRows, "load user").GetType())
+	assertions.Equal(BadRequest, WrapWith(sql.ErrNoRows, "load user", WithType(BadRequest)).GetType(), "Check explicit type")
+	assertions.Equal(DefaultType, Wrap(errNativeReference, "native").GetType())
+	assertions.Equal(sql.ErrNoRows, Wrap(sql.ErrNoRows, "load user").Unwrap())
+}
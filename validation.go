@@ -0,0 +1,168 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ViolationsBaggageKey is a baggage key of field violations of InvalidArguments errors
+const ViolationsBaggageKey = "violations"
+
+// RedactedValue replaces rejected values of redacted violations
+const RedactedValue = "[REDACTED]"
+
+// Violation describes single failed validation rule of field
+type Violation struct {
+	// Field is a path of field, e.g. "items[2].price"
+	Field string `json:"field"`
+	// Rule is a code of failed rule, e.g. "required" or "max"
+	Rule string `json:"rule"`
+	// Message describes violation
+	Message string `json:"message"`
+	// Value is a rejected value
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Validation collects field violations and renders them as one InvalidArguments error
+//
+//	v := errors.NewValidation()
+//	if req.Name == "" {
+//		v.Add("name", "required", "name is required", req.Name)
+//	}
+//	for i, item := range req.Items {
+//		v.Merge(errors.Index("items", i), validateItem(item))
+//	}
+//	if err := v.Err(); err != nil {
+//		return err
+//	}
+type Validation struct {
+	violations []Violation
+}
+
+// NewValidation create empty violations collection
+func NewValidation() *Validation {
+	return &Validation{}
+}
+
+// Add adds violation of field with rejected value
+func (v *Validation) Add(field, rule, message string, value interface{}) *Validation {
+	v.violations = append(v.violations, Violation{Field: field, Rule: rule, Message: message, Value: value})
+	return v
+}
+
+// Merge adds violations of nested struct, their field paths are prefixed with prefix
+func (v *Validation) Merge(prefix string, nested *Validation) *Validation {
+	if nested == nil {
+		return v
+	}
+	for _, violation := range nested.violations {
+		violation.Field = JoinField(prefix, violation.Field)
+		v.violations = append(v.violations, violation)
+	}
+	return v
+}
+
+// Redact replaces rejected values of specified fields with RedactedValue, values of all fields are redacted
+// if fields are not specified
+func (v *Validation) Redact(fields ...string) *Validation {
+	for k := range v.violations {
+		if len(fields) == 0 || containsString(fields, v.violations[k].Field) {
+			v.violations[k].Value = RedactedValue
+		}
+	}
+	return v
+}
+
+// IsEmpty returns true if there are no violations
+func (v *Validation) IsEmpty() bool {
+	return len(v.violations) == 0
+}
+
+// Violations returns collected violations
+func (v *Validation) Violations() []Violation {
+	return v.violations
+}
+
+// Err returns InvalidArguments error with collected violations or nil if there are no violations
+func (v *Validation) Err() CustomError {
+	return v.err(1)
+}
+
+// ErrWithCallerSkip is analogous to Err, but skip frames of functions that validate values on behalf of their callers,
+// so path of error is the place where validation is called, see WithCallerSkip
+func (v *Validation) ErrWithCallerSkip(skip int) CustomError {
+	return v.err(skip + 1)
+}
+
+// err create error with the stack that starts from the frame skipped by skip
+func (v *Validation) err(skip int) CustomError {
+	if v.IsEmpty() {
+		return nil
+	}
+	violations := make([]Violation, len(v.violations))
+	copy(violations, v.violations)
+	message := fmt.Sprintf("validation failed for %s", strings.Join(v.fields(), ", "))
+	return newCustomErr(
+		InvalidArguments, ErrorBaggage{ViolationsBaggageKey: violations}, DefaultLevel, DefaultSeverity,
+		&stackErr{err: &transparent{err: errors.New(message)}, stack: callers(skip)},
+	)
+}
+
+// fields returns unique fields of violations
+func (v *Validation) fields() []string {
+	var result []string
+	for _, violation := range v.violations {
+		if !containsString(result, violation.Field) {
+			result = append(result, violation.Field)
+		}
+	}
+	return result
+}
+
+// Violations returns field violations of the first error in the chain that has them
+func Violations(err error) []Violation {
+	layer, ok := FindInStack(err, HasBaggageKey(ViolationsBaggageKey))
+	if !ok {
+		return nil
+	}
+	switch v := layer.GetBaggage()[ViolationsBaggageKey].(type) {
+	case []Violation:
+		return v
+	default:
+		// decoded errors have violations in generic form
+		var result []Violation
+		if data, err := json.Marshal(v); err == nil && json.Unmarshal(data, &result) == nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// JoinField joins field path prefix and field, e.g. "items[2]" and "price" are joined into "items[2].price"
+func JoinField(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case strings.HasPrefix(field, "["):
+		return prefix + field
+	}
+	return prefix + "." + field
+}
+
+// Index returns path of slice element, e.g. Index("items", 2) returns "items[2]"
+func Index(field string, index int) string {
+	return fmt.Sprintf("%s[%d]", field, index)
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
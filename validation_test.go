@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validateItem(price int) *Validation {
+	v := NewValidation()
+	if price <= 0 {
+		v.Add("price", "min", "price must be positive", price)
+	}
+	return v
+}
+
+func TestValidation(t *testing.T) {
+	assertions := assert.New(t)
+
+	v := NewValidation()
+	assertions.True(v.IsEmpty())
+	assertions.Nil(v.Err())
+
+	v.Add("name", "required", "name is required", "")
+	v.Add("password", "min", "password is too short", "123")
+	for k, price := range []int{10, 0, -1} {
+		v.Merge(Index("items", k), validateItem(price))
+	}
+	v.Merge("address", NewValidation().Add("city", "required", "city is required", nil))
+	v.Merge("", NewValidation().Add("email", "email", "invalid email", "a@"))
+	v.Merge("matrix", NewValidation().Add("[1]", "required", "required", nil))
+	v.Redact("password")
+
+	err := v.Err()
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Equal(InvalidArguments, err.GetType())
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check err path")
+	assertions.Equal("validation failed for name, password, items[1].price, items[2].price, address.city, email, matrix[1]", err.Error())
+
+	violations := Violations(Wrap(err, "create order"))
+	assertions.Equal([]Violation{
+		{Field: "name", Rule: "required", Message: "name is required", Value: ""},
+		{Field: "password", Rule: "min", Message: "password is too short", Value: RedactedValue},
+		{Field: "items[1].price", Rule: "min", Message: "price must be positive", Value: 0},
+		{Field: "items[2].price", Rule: "min", Message: "price must be positive", Value: -1},
+		{Field: "address.city", Rule: "required", Message: "city is required"},
+		{Field: "email", Rule: "email", Message: "invalid email", Value: "a@"},
+		{Field: "matrix[1]", Rule: "required", Message: "required"},
+	}, violations)
+
+	v.Add("late", "required", "late", nil)
+	assertions.Len(Violations(err), 7, "Check that error is not changed by validation")
+	assertions.Nil(Violations(NotFound.NewBase("not found")))
+}
+
+// validateName validates name on behalf of its caller
+func validateName(name string) CustomError {
+	v := NewValidation()
+	if name == "" {
+		v.Add("name", "required", "name is required", name)
+	}
+	return v.ErrWithCallerSkip(1)
+}
+
+func TestValidation_ErrWithCallerSkip(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := validateName("")
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that helper frame is skipped")
+	assertions.Equal("validation failed for name", err.Error())
+	assertions.Nil(validateName("admin"))
+}
+
+func TestViolations_JSONUnsupportedValues(t *testing.T) {
+	assertions := assert.New(t)
+	err := NewValidation().
+		Add("ratio", "min", "must be positive", math.NaN()).
+		Add("limit", "max", "must be finite", math.Inf(1)).
+		Add("offset", "min", "must be finite", math.Inf(-1)).
+		Err()
+
+	data, marshalErr := json.Marshal(err)
+	assertions.NoError(marshalErr)
+	var record ErrorRecord
+	assertions.NoError(json.Unmarshal(data, &record))
+	assertions.Equal([]Violation{
+		{Field: "ratio", Rule: "min", Message: "must be positive", Value: "NaN"},
+		{Field: "limit", Rule: "max", Message: "must be finite", Value: "+Inf"},
+		{Field: "offset", Rule: "min", Message: "must be finite", Value: "-Inf"},
+	}, record.Violations)
+	assertions.True(math.IsNaN(Violations(err)[0].Value.(float64)), "Check that violations of error are not changed")
+
+	_, marshalErr = json.Marshal(NewPublicError(err, ExposePublic))
+	assertions.NoError(marshalErr, "Check public representation")
+}
+
+func TestViolations_JSON(t *testing.T) {
+	assertions := assert.New(t)
+	err := NewValidation().Add("name", "required", "name is required", nil).Redact().Err()
+
+	data, marshalErr := json.Marshal(err)
+	assertions.NoError(marshalErr)
+	var record ErrorRecord
+	assertions.NoError(json.Unmarshal(data, &record))
+	expected := []Violation{{Field: "name", Rule: "required", Message: "name is required", Value: RedactedValue}}
+	assertions.Equal(expected, record.Violations)
+	assertions.Equal(1, strings.Count(string(data), `"violations"`), "Check that violations are encoded once")
+
+	assertions.Equal(expected, Violations(NewRemote("orders", record)), "Check violations of decoded error")
+
+	data, marshalErr = json.Marshal(Wrap(WrapWith(err, "validate order", WithBaggage(ErrorBaggage{"orderID": 1})), "create order"))
+	assertions.NoError(marshalErr)
+	assertions.Equal(1, strings.Count(string(data), `"violations"`), "Check that violations are not repeated in layers")
+	assertions.NoError(json.Unmarshal(data, &record))
+	assertions.Equal(ErrorBaggage{"orderID": float64(1)}, record.Stack[1].Baggage)
+	assertions.Equal(expected, Violations(NewRemote("orders", record)))
+}
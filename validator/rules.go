@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// builtinRules are registered in every validator
+var builtinRules = map[string]Rule{
+	"required": required,
+	"min":      minimum,
+	"max":      maximum,
+	"email":    email,
+	"oneof":    oneOf,
+}
+
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// required checks that value is not zero, empty strings, slices and maps are not allowed
+func required(value reflect.Value, _ string) error {
+	if !value.IsValid() || value.IsZero() {
+		return fmt.Errorf("is required")
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		if value.Len() == 0 {
+			return fmt.Errorf("is required")
+		}
+	}
+	return nil
+}
+
+// minimum checks min value of numbers or min length of strings, slices and maps
+func minimum(value reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("has invalid min parameter %q", param)
+	}
+	size, unit, ok := measure(value)
+	if !ok {
+		return nil
+	}
+	if size < limit {
+		if unit != "" {
+			return fmt.Errorf("must have at least %s %s", param, unit)
+		}
+		return fmt.Errorf("must be at least %s", param)
+	}
+	return nil
+}
+
+// maximum checks max value of numbers or max length of strings, slices and maps
+func maximum(value reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("has invalid max parameter %q", param)
+	}
+	size, unit, ok := measure(value)
+	if !ok {
+		return nil
+	}
+	if size > limit {
+		if unit != "" {
+			return fmt.Errorf("must have at most %s %s", param, unit)
+		}
+		return fmt.Errorf("must be at most %s", param)
+	}
+	return nil
+}
+
+// email checks that string is an email address, empty strings are allowed
+func email(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || value.Len() == 0 {
+		return nil
+	}
+	if !emailRegexp.MatchString(value.String()) {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
+
+// oneOf checks that value is one of space separated values, empty strings are allowed
+func oneOf(value reflect.Value, param string) error {
+	if !value.IsValid() || (value.Kind() == reflect.String && value.Len() == 0) {
+		return nil
+	}
+	actual := fmt.Sprint(value.Interface())
+	for _, v := range strings.Fields(param) {
+		if v == actual {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of [%s]", param)
+}
+
+// measure returns number value or length of value, unit of length is returned for strings, slices and maps
+func measure(value reflect.Value) (size float64, unit string, ok bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), "characters", true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), "elements", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), "", true
+	}
+	return 0, "", false
+}
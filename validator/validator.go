@@ -0,0 +1,234 @@
+// Package validator validates structs by `validate` field tags and returns InvalidArguments custom errors
+// with field violations, see errors.Validation.
+//
+//	type CreateUser struct {
+//		Name  string   `json:"name" validate:"required,max=64"`
+//		Email string   `json:"email" validate:"required,email"`
+//		Role  string   `json:"role" validate:"oneof=admin user"`
+//		Tags  []string `json:"tags" validate:"max=10"`
+//	}
+//
+//	if err := validator.Validate(req); err != nil {
+//		return err
+//	}
+//
+// Field paths are built from json names of fields, nested structs, pointers to structs and slices of structs
+// are validated recursively (e.g. "items[2].price"), cyclic references are not walked again. Map values are not
+// walked, so structs stored in maps must be validated separately. Values of fields
+// with "redact" tag option are redacted, rules of fields with "omitempty" tag option are skipped for zero values.
+// Path of returned error is the place of Validate call
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+)
+
+// TagName is a name of struct field tag with rules
+const TagName = "validate"
+
+// Tag options that are not rules
+const (
+	// redactOption hides rejected value of field
+	redactOption = "redact"
+	// omitEmptyOption skips rules of field with zero value
+	omitEmptyOption = "omitempty"
+)
+
+// Rule checks field value, param is a rule parameter from the tag (e.g. "64" for "max=64").
+// Returned error message is used as violation message
+type Rule func(value reflect.Value, param string) error
+
+// Validator validates structs with registered rules, it is safe for concurrent use
+type Validator struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// New create validator with built-in rules: required, min, max, email, oneof
+func New() *Validator {
+	v := &Validator{rules: make(map[string]Rule)}
+	for name, rule := range builtinRules {
+		v.rules[name] = rule
+	}
+	return v
+}
+
+// defaultValidator is used by package level functions
+var defaultValidator = New()
+
+// Validate validates struct with the default validator
+func Validate(value interface{}) cErrors.CustomError {
+	return defaultValidator.check(value, 1)
+}
+
+// RegisterRule registers rule in the default validator
+func RegisterRule(name string, rule Rule) {
+	defaultValidator.RegisterRule(name, rule)
+}
+
+// RegisterRule registers own rule or replaces existing one
+func (v *Validator) RegisterRule(name string, rule Rule) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[name] = rule
+}
+
+// Validate validates struct or pointer to struct, it returns InvalidArguments error with violations
+// or nil if value is valid. Unknown rules are reported as InternalError. Path of error is the place of Validate call
+func (v *Validator) Validate(value interface{}) cErrors.CustomError {
+	return v.check(value, 1)
+}
+
+// check validates value, skip is a count of validator frames above check that are skipped in path of error
+func (v *Validator) check(value interface{}, skip int) cErrors.CustomError {
+	validation, err := v.validate(reflect.ValueOf(value), make(map[visit]bool))
+	if err != nil {
+		return cErrors.Make(cErrors.ErrorMessage(err.Error()), cErrors.WithType(cErrors.InternalError), cErrors.WithCallerSkip(skip+1))
+	}
+	return validation.ErrWithCallerSkip(skip + 1)
+}
+
+// visit is a pointer that is being walked, it is used to stop walking of cyclic values
+type visit struct {
+	ptr       uintptr
+	valueType reflect.Type
+}
+
+// validate walks value and collects violations of its fields, pointers of the walked path are stored in visited
+func (v *Validator) validate(value reflect.Value, visited map[visit]bool) (*cErrors.Validation, error) {
+	validation := cErrors.NewValidation()
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return validation, nil
+		}
+		if value.Kind() == reflect.Ptr {
+			key := visit{ptr: value.Pointer(), valueType: value.Type()}
+			if visited[key] {
+				// value is already validated by one of the parents
+				return validation, nil
+			}
+			visited[key] = true
+			defer delete(visited, key)
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		valueType := value.Type()
+		for k := 0; k < valueType.NumField(); k++ {
+			field := valueType.Field(k)
+			if field.PkgPath != "" && !field.Anonymous {
+				// unexported field
+				continue
+			}
+			name := fieldName(field)
+			if name == "-" {
+				continue
+			}
+			if err := v.validateField(validation, name, value.Field(k), field.Tag.Get(TagName)); err != nil {
+				return nil, err
+			}
+			nested, err := v.validate(value.Field(k), visited)
+			if err != nil {
+				return nil, err
+			}
+			if field.Anonymous {
+				name = ""
+			}
+			validation.Merge(name, nested)
+		}
+	case reflect.Slice, reflect.Array:
+		for k := 0; k < value.Len(); k++ {
+			nested, err := v.validate(value.Index(k), visited)
+			if err != nil {
+				return nil, err
+			}
+			validation.Merge(cErrors.Index("", k), nested)
+		}
+	}
+	return validation, nil
+}
+
+// validateField checks field value with rules from the tag
+func (v *Validator) validateField(validation *cErrors.Validation, name string, value reflect.Value, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	rules := strings.Split(tag, ",")
+	redact := false
+	for _, rule := range rules {
+		switch strings.TrimSpace(rule) {
+		case redactOption:
+			redact = true
+		case omitEmptyOption:
+			if !value.IsValid() || value.IsZero() {
+				return nil
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		ruleName, param := splitRule(rule)
+		if ruleName == "" || ruleName == redactOption || ruleName == omitEmptyOption {
+			continue
+		}
+		v.mu.RLock()
+		check, ok := v.rules[ruleName]
+		v.mu.RUnlock()
+		if !ok {
+			return fmt.Errorf("unknown validation rule %q of field %s", ruleName, name)
+		}
+		// only required rule is applied to empty pointers
+		if ruleName != "required" && isNilPointer(value) {
+			continue
+		}
+		if err := check(indirect(value), param); err != nil {
+			var rejected interface{}
+			if value.IsValid() && value.CanInterface() {
+				rejected = indirect(value).Interface()
+			}
+			if redact {
+				rejected = cErrors.RedactedValue
+			}
+			validation.Add(name, ruleName, err.Error(), rejected)
+		}
+	}
+	return nil
+}
+
+// fieldName returns json name of field or field name if json tag is not set
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// splitRule splits rule into name and parameter, e.g. "max=64" into "max" and "64"
+func splitRule(rule string) (string, string) {
+	rule = strings.TrimSpace(rule)
+	if index := strings.Index(rule, "="); index >= 0 {
+		return rule[:index], rule[index+1:]
+	}
+	return rule, ""
+}
+
+func isNilPointer(value reflect.Value) bool {
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// indirect returns value that pointer points to, nil pointers are returned as is
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value
+}
@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	cErrors "github.com/Darevski/go-custom-errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	SKU   string  `json:"sku" validate:"required,sku"`
+	Price float64 `json:"price" validate:"min=0.01"`
+}
+
+type address struct {
+	City string `validate:"required"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"createdBy" validate:"required"`
+}
+
+type createOrder struct {
+	Audit
+	Name     string   `json:"name" validate:"required,max=5"`
+	Email    string   `json:"email,omitempty" validate:"email"`
+	Password string   `json:"password" validate:"omitempty,min=8,redact"`
+	Role     string   `json:"role" validate:"oneof=admin user"`
+	Count    *int     `json:"count" validate:"min=1"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Items    []item   `json:"items" validate:"required"`
+	Address  *address `json:"address"`
+	Ignored  string   `json:"-" validate:"required"`
+	internal string
+}
+
+func TestValidate(t *testing.T) {
+	assertions := assert.New(t)
+	validator := New()
+	validator.RegisterRule("sku", func(value reflect.Value, param string) error {
+		if len(value.String()) != 6 {
+			return errors.New("must have 6 symbols")
+		}
+		return nil
+	})
+
+	zero := 0
+	order := createOrder{
+		Name:     "too long name",
+		Email:    "not an email",
+		Password: "secret",
+		Role:     "root",
+		Count:    &zero,
+		Tags:     []string{"a", "b", "c"},
+		Items:    []item{{SKU: "ABCDEF", Price: 1}, {SKU: "ABC", Price: 0}},
+		Address:  &address{},
+	}
+	err := validator.Validate(&order)
+	assertions.Equal(cErrors.InvalidArguments, err.GetType())
+	assertions.Equal([]cErrors.Violation{
+		{Field: "createdBy", Rule: "required", Message: "is required", Value: ""},
+		{Field: "name", Rule: "max", Message: "must have at most 5 characters", Value: "too long name"},
+		{Field: "email", Rule: "email", Message: "must be a valid email address", Value: "not an email"},
+		{Field: "password", Rule: "min", Message: "must have at least 8 characters", Value: cErrors.RedactedValue},
+		{Field: "role", Rule: "oneof", Message: "must be one of [admin user]", Value: "root"},
+		{Field: "count", Rule: "min", Message: "must be at least 1", Value: 0},
+		{Field: "tags", Rule: "max", Message: "must have at most 2 elements", Value: []string{"a", "b", "c"}},
+		{Field: "items[1].sku", Rule: "sku", Message: "must have 6 symbols", Value: "ABC"},
+		{Field: "items[1].price", Rule: "min", Message: "must be at least 0.01", Value: float64(0)},
+		{Field: "address.City", Rule: "required", Message: "is required", Value: ""},
+	}, cErrors.Violations(err))
+
+	valid := createOrder{
+		Audit: Audit{CreatedBy: "admin"},
+		Name:  "name",
+		Items: []item{{SKU: "ABCDEF", Price: 1}},
+	}
+	assertions.Nil(validator.Validate(valid), "Check that empty optional fields are valid")
+	assertions.Nil(validator.Validate(nil))
+
+	err = Validate(valid)
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Equal(cErrors.InternalError, err.GetType(), "Check unknown rule")
+	assertions.Contains(err.Error(), `unknown validation rule "sku"`)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check path of unknown rule error")
+}
+
+func TestValidate_Path(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := Validate(address{})
+	_, fn, line, _ := runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that validator frames are skipped")
+
+	err = New().Validate(&address{})
+	_, fn, line, _ = runtime.Caller(0)
+	assertions.Contains(err.GetPath().String(), fmt.Sprintf("%s:%d", fn, line-1), "Check that validator frames are skipped")
+}
+
+// node is a self-referencing struct
+type node struct {
+	Name     string  `json:"name" validate:"required"`
+	Parent   *node   `json:"parent"`
+	Children []*node `json:"children"`
+}
+
+func TestValidate_Cycle(t *testing.T) {
+	assertions := assert.New(t)
+	root := &node{}
+	child := &node{Name: "child", Parent: root}
+	root.Children = []*node{child, {Parent: root}}
+	root.Parent = root
+
+	violations := cErrors.Violations(Validate(root))
+	assertions.Equal([]cErrors.Violation{
+		{Field: "name", Rule: "required", Message: "is required", Value: ""},
+		{Field: "children[1].name", Rule: "required", Message: "is required", Value: ""},
+	}, violations, "Check that cyclic references are walked once")
+
+	shared := &address{}
+	violations = cErrors.Violations(Validate(struct {
+		Home *address `json:"home"`
+		Work *address `json:"work"`
+	}{Home: shared, Work: shared}))
+	assertions.Len(violations, 2, "Check that shared pointers are not cycles")
+}
+
+func TestValidate_Required(t *testing.T) {
+	assertions := assert.New(t)
+
+	var empty struct {
+		Pointer *int           `validate:"required"`
+		Slice   []int          `validate:"required"`
+		Map     map[string]int `validate:"required"`
+		Number  int            `validate:"required"`
+	}
+	violations := cErrors.Violations(Validate(empty))
+	assertions.Len(violations, 4)
+	for _, v := range violations {
+		assertions.Equal("required", v.Rule)
+	}
+}